@@ -0,0 +1,236 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	SMTPSecurityNone     = "none"
+	SMTPSecuritySTARTTLS = "starttls"
+	SMTPSecurityTLS      = "tls"
+)
+
+const defaultEmailSubject = "Você tem uma nova notificação"
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+	Security string
+	Subject  string
+}
+
+func SMTPConfigFromEnv() (*SMTPConfig, error) {
+	config := &SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     os.Getenv("SMTP_PORT"),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+		Security: strings.ToLower(os.Getenv("SMTP_SECURITY")),
+		Subject:  os.Getenv("SMTP_SUBJECT"),
+	}
+
+	if config.Port == "" {
+		config.Port = "25"
+	}
+
+	if config.Security == "" {
+		config.Security = SMTPSecurityNone
+	}
+
+	if config.Subject == "" {
+		config.Subject = defaultEmailSubject
+	}
+
+	if config.Host == "" {
+		return nil, fmt.Errorf("missing SMTP_HOST")
+	}
+
+	if _, err := mail.ParseAddress(config.From); err != nil {
+		return nil, fmt.Errorf("invalid SMTP_FROM %q", config.From)
+	}
+
+	switch config.Security {
+	case SMTPSecurityNone, SMTPSecuritySTARTTLS, SMTPSecurityTLS:
+	default:
+		return nil, fmt.Errorf("invalid SMTP_SECURITY %q", config.Security)
+	}
+
+	return config, nil
+}
+
+type SMTPSender struct {
+	config *SMTPConfig
+}
+
+func NewSMTPSender(config *SMTPConfig) *SMTPSender {
+	return &SMTPSender{config: config}
+}
+
+func (s *SMTPSender) Send(ctx context.Context, message *Message) error {
+	to, err := mail.ParseAddress(message.Recipient)
+
+	if err != nil {
+		return fmt.Errorf("invalid email recipient %q: %w", message.Recipient, err)
+	}
+
+	subject := message.Subject
+
+	if subject == "" {
+		subject = s.config.Subject
+	}
+
+	body, err := buildMIMEMessage(s.config.From, to.String(), subject, message.Body, message.HtmlBody, time.Now())
+
+	if err != nil {
+		return err
+	}
+
+	client, err := s.dial(ctx)
+
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if s.config.Security == SMTPSecuritySTARTTLS {
+		if err := client.StartTLS(&tls.Config{ServerName: s.config.Host}); err != nil {
+			return err
+		}
+	}
+
+	if s.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)); err != nil {
+			return err
+		}
+	}
+
+	from, err := mail.ParseAddress(s.config.From)
+
+	if err != nil {
+		return err
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(body); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (s *SMTPSender) dial(ctx context.Context) (*smtp.Client, error) {
+	address := net.JoinHostPort(s.config.Host, s.config.Port)
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if s.config.Security == SMTPSecurityTLS {
+		conn = tls.Client(conn, &tls.Config{ServerName: s.config.Host})
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func buildMIMEMessage(from, to, subject, text, htmlBody string, date time.Time) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+
+	headers := []string{
+		"From: " + from,
+		"To: " + to,
+		"Subject: " + mime.QEncoding.Encode("utf-8", subject),
+		"Date: " + date.Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary=" + writer.Boundary(),
+	}
+	buffer.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	if htmlBody == "" {
+		htmlBody = "<html><body><p>" + strings.ReplaceAll(html.EscapeString(text), "\n", "<br>") + "</p></body></html>"
+	}
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", htmlBody},
+	}
+
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := quotedprintable.NewWriter(partWriter)
+
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
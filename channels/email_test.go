@@ -0,0 +1,150 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"testing"
+	"time"
+)
+
+type SMTPSenderTestSuite struct {
+	suite.Suite
+	mailHogContainer *testhelpers.MailHogContainer
+	sender           *SMTPSender
+	ctx              context.Context
+}
+
+type mailHogMessages struct {
+	Total int `json:"total"`
+	Items []struct {
+		Raw struct {
+			From string   `json:"From"`
+			To   []string `json:"To"`
+			Data string   `json:"Data"`
+		} `json:"Raw"`
+	} `json:"items"`
+}
+
+func (suite *SMTPSenderTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	mailHogContainer, err := testhelpers.NewMailHogContainer(suite.ctx)
+	require.Nil(suite.T(), err, "failed to start mailhog container: %v", err)
+
+	suite.mailHogContainer = mailHogContainer
+	suite.sender = NewSMTPSender(&SMTPConfig{
+		Host:     mailHogContainer.SMTPHost,
+		Port:     mailHogContainer.SMTPPort,
+		From:     "Magalu <noreply@magalu.com>",
+		Security: SMTPSecurityNone,
+		Subject:  "Notificação",
+	})
+}
+
+func (suite *SMTPSenderTestSuite) TearDownSuite() {
+	if err := suite.mailHogContainer.Terminate(suite.ctx); err != nil {
+		suite.T().Fatalf("failed to terminate mailHogContainer: %s", err)
+	}
+}
+
+func TestSMTPSenderTestSuite(t *testing.T) {
+	suite.Run(t, new(SMTPSenderTestSuite))
+}
+
+func (suite *SMTPSenderTestSuite) TestSuccessSend() {
+	t := suite.T()
+
+	t.Run("Should deliver email to the SMTP server", func(t *testing.T) {
+		message := &Message{NotificationID: 1, Type: "email", Recipient: "test@example.com", Body: "Olá!\nSeu pedido saiu para entrega."}
+
+		err := suite.sender.Send(suite.ctx, message)
+		require.Nilf(t, err, "failed to send email: %v", err)
+
+		response, err := http.Get(suite.mailHogContainer.APIURL + "/api/v2/messages")
+		require.Nilf(t, err, "failed to list messages: %v", err)
+		defer response.Body.Close()
+
+		var messages mailHogMessages
+		err = json.NewDecoder(response.Body).Decode(&messages)
+		require.Nilf(t, err, "failed to decode messages: %v", err)
+
+		require.Equal(t, 1, messages.Total)
+		assert.Equal(t, "noreply@magalu.com", messages.Items[0].Raw.From)
+		assert.Equal(t, []string{"test@example.com"}, messages.Items[0].Raw.To)
+		assert.Contains(t, messages.Items[0].Raw.Data, "multipart/alternative")
+	})
+}
+
+func (suite *SMTPSenderTestSuite) TestErrorOnSendWithInvalidRecipient() {
+	t := suite.T()
+
+	t.Run("Should return error when recipient is not an email address", func(t *testing.T) {
+		message := &Message{NotificationID: 1, Type: "email", Recipient: "1234567890", Body: "Hello"}
+
+		err := suite.sender.Send(suite.ctx, message)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestBuildMIMEMessage(t *testing.T) {
+	t.Run("Should build a multipart message with text and html parts", func(t *testing.T) {
+		body, err := buildMIMEMessage("noreply@magalu.com", "test@example.com", "Notificação", "Olá <cliente>", "", time.Now())
+		require.Nilf(t, err, "failed to build message: %v", err)
+
+		message, err := mail.ReadMessage(bytes.NewReader(body))
+		require.Nilf(t, err, "failed to parse message: %v", err)
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+		require.Nilf(t, err, "failed to decode subject: %v", err)
+
+		mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+		require.Nilf(t, err, "failed to parse content type: %v", err)
+
+		assert.Equal(t, "Notificação", subject)
+		assert.Equal(t, "multipart/alternative", mediaType)
+
+		reader := multipart.NewReader(message.Body, params["boundary"])
+		contents := make(map[string]string)
+
+		for {
+			part, err := reader.NextPart()
+
+			if err == io.EOF {
+				break
+			}
+
+			require.Nilf(t, err, "failed to read part: %v", err)
+
+			content, err := io.ReadAll(part)
+			require.Nilf(t, err, "failed to read part content: %v", err)
+
+			contents[part.Header.Get("Content-Type")] = string(content)
+		}
+
+		assert.Equal(t, "Olá <cliente>", contents["text/plain; charset=utf-8"])
+		assert.Contains(t, contents["text/html; charset=utf-8"], "Olá &lt;cliente&gt;")
+	})
+	t.Run("Should use the rendered html body when informed", func(t *testing.T) {
+		body, err := buildMIMEMessage("noreply@magalu.com", "test@example.com", "Notificação", "Olá", "<h1>Olá</h1>", time.Now())
+		require.Nilf(t, err, "failed to build message: %v", err)
+
+		message, err := mail.ReadMessage(bytes.NewReader(body))
+		require.Nilf(t, err, "failed to parse message: %v", err)
+
+		content, err := io.ReadAll(message.Body)
+		require.Nilf(t, err, "failed to read message body: %v", err)
+
+		assert.Contains(t, string(content), "<h1>Ol=C3=A1</h1>")
+	})
+}
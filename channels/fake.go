@@ -0,0 +1,39 @@
+package channels
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeSender records every message it receives instead of delivering it.
+// When Err is set, Send fails with it without recording the message.
+type FakeSender struct {
+	Err error
+
+	mu       sync.Mutex
+	messages []*Message
+}
+
+func NewFakeSender() *FakeSender {
+	return &FakeSender{}
+}
+
+func (s *FakeSender) Send(ctx context.Context, message *Message) error {
+	if s.Err != nil {
+		return s.Err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, message)
+
+	return nil
+}
+
+func (s *FakeSender) Messages() []*Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*Message(nil), s.messages...)
+}
@@ -0,0 +1,18 @@
+package channels
+
+import (
+	"context"
+	"log"
+)
+
+type LogSender struct{}
+
+func NewLogSender() *LogSender {
+	return &LogSender{}
+}
+
+func (s *LogSender) Send(ctx context.Context, message *Message) error {
+	log.Printf("Enviando notificação %d (%s) para %s: %s", message.NotificationID, message.Type, message.Recipient, message.Body)
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type Registry struct {
+	senders map[string]Sender
+}
+
+func NewRegistry() *Registry {
+	return &Registry{senders: make(map[string]Sender)}
+}
+
+func (r *Registry) Register(notificationType string, sender Sender) {
+	r.senders[notificationType] = sender
+}
+
+// Validate fails when any of the given types has no registered sender, so a
+// misconfiguration is caught on startup instead of when the first message is due.
+func (r *Registry) Validate(notificationTypes []string) error {
+	missing := make([]string, 0)
+
+	for _, notificationType := range notificationTypes {
+		if _, ok := r.senders[notificationType]; !ok {
+			missing = append(missing, notificationType)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("no sender registered for notification types: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+func (r *Registry) Send(ctx context.Context, message *Message) error {
+	sender, ok := r.senders[message.Type]
+
+	if !ok {
+		return fmt.Errorf("no sender registered for notification type %q", message.Type)
+	}
+
+	return sender.Send(ctx, message)
+}
@@ -0,0 +1,57 @@
+package channels
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSuccessSendOnRegistry(t *testing.T) {
+	t.Run("Should route message to the sender registered for its type", func(t *testing.T) {
+		sms := NewFakeSender()
+		email := NewFakeSender()
+
+		registry := NewRegistry()
+		registry.Register("sms", sms)
+		registry.Register("email", email)
+
+		message := &Message{NotificationID: 1, Type: "sms", Recipient: "1234567890", Body: "Hello"}
+
+		err := registry.Send(context.Background(), message)
+		require.Nilf(t, err, "failed to send message: %v", err)
+
+		assert.Equal(t, []*Message{message}, sms.Messages())
+		assert.Empty(t, email.Messages())
+	})
+}
+
+func TestMissingSenderOnRegistry(t *testing.T) {
+	t.Run("Should return error when sending a type without sender", func(t *testing.T) {
+		registry := NewRegistry()
+
+		err := registry.Send(context.Background(), &Message{NotificationID: 1, Type: "push"})
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Should fail validation when a type has no sender", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("sms", NewFakeSender())
+
+		err := registry.Validate([]string{"sms", "push", "whatsapp"})
+
+		require.NotNil(t, err)
+		assert.Contains(t, err.Error(), "push, whatsapp")
+	})
+
+	t.Run("Should pass validation when every type has a sender", func(t *testing.T) {
+		registry := NewRegistry()
+		registry.Register("sms", NewFakeSender())
+		registry.Register("push", NewFakeSender())
+
+		err := registry.Validate([]string{"sms", "push"})
+
+		assert.Nil(t, err)
+	})
+}
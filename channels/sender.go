@@ -0,0 +1,30 @@
+package channels
+
+import (
+	"context"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+)
+
+type Sender interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// Message is what a channel delivers. Subject and HtmlBody are only filled for
+// notifications rendered from a template that defines them.
+type Message struct {
+	NotificationID int64
+	Type           string
+	Recipient      string
+	Subject        string
+	Body           string
+	HtmlBody       string
+}
+
+func NewMessage(notification *notifications.Notification) *Message {
+	return &Message{
+		NotificationID: notification.Id,
+		Type:           notification.Type,
+		Recipient:      notification.Recipient,
+		Body:           notification.Message,
+	}
+}
@@ -0,0 +1,77 @@
+package channels
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	EncodingGSM7 = "GSM-7"
+	EncodingUCS2 = "UCS-2"
+)
+
+const (
+	gsm7SingleSegment = 160
+	gsm7MultiSegment  = 153
+	ucs2SingleSegment = 70
+	ucs2MultiSegment  = 67
+)
+
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extension holds the characters sent as an escape sequence, taking two septets.
+const gsm7Extension = "\f^{}\\[~]|€"
+
+type SMSInfo struct {
+	Encoding         string   `json:"encoding"`
+	Length           int      `json:"length"`
+	Segments         int      `json:"segments"`
+	NonGSMCharacters []string `json:"non_gsm_characters,omitempty"`
+}
+
+// AnalyzeSMS reports how body is encoded on the carrier network and how many
+// segments it is split into. A single character outside the GSM-7 alphabet turns
+// the whole message into UCS-2, whose segments hold less than half the characters.
+func AnalyzeSMS(body string) *SMSInfo {
+	septets := 0
+	nonGSM := make([]string, 0)
+	seen := make(map[rune]bool)
+
+	for _, char := range body {
+		switch {
+		case strings.ContainsRune(gsm7Basic, char):
+			septets++
+		case strings.ContainsRune(gsm7Extension, char):
+			septets += 2
+		case !seen[char]:
+			seen[char] = true
+			nonGSM = append(nonGSM, string(char))
+		}
+	}
+
+	if len(nonGSM) == 0 {
+		return &SMSInfo{
+			Encoding: EncodingGSM7,
+			Length:   septets,
+			Segments: segments(septets, gsm7SingleSegment, gsm7MultiSegment),
+		}
+	}
+
+	units := len(utf16.Encode([]rune(body)))
+
+	return &SMSInfo{
+		Encoding:         EncodingUCS2,
+		Length:           units,
+		Segments:         segments(units, ucs2SingleSegment, ucs2MultiSegment),
+		NonGSMCharacters: nonGSM,
+	}
+}
+
+func segments(length, single, multi int) int {
+	if length <= single {
+		return 1
+	}
+
+	return (length + multi - 1) / multi
+}
@@ -0,0 +1,29 @@
+package channels
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeSMS(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		expected *SMSInfo
+	}{
+		{"Should count a short GSM-7 message as one segment", "Seu pedido saiu para entrega", &SMSInfo{Encoding: EncodingGSM7, Length: 28, Segments: 1}},
+		{"Should count extension characters as two septets", "Total: 10€ {ok}", &SMSInfo{Encoding: EncodingGSM7, Length: 18, Segments: 1}},
+		{"Should fit 160 GSM-7 characters in one segment", strings.Repeat("a", 160), &SMSInfo{Encoding: EncodingGSM7, Length: 160, Segments: 1}},
+		{"Should split long GSM-7 messages in segments of 153", strings.Repeat("a", 161), &SMSInfo{Encoding: EncodingGSM7, Length: 161, Segments: 2}},
+		{"Should use UCS-2 for characters outside GSM-7", "Olá, João", &SMSInfo{Encoding: EncodingUCS2, Length: 9, Segments: 1, NonGSMCharacters: []string{"á", "ã"}}},
+		{"Should count emoji as two UCS-2 units", "Oi 😀", &SMSInfo{Encoding: EncodingUCS2, Length: 5, Segments: 1, NonGSMCharacters: []string{"😀"}}},
+		{"Should split long UCS-2 messages in segments of 67", strings.Repeat("ã", 71), &SMSInfo{Encoding: EncodingUCS2, Length: 71, Segments: 2, NonGSMCharacters: []string{"ã"}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, AnalyzeSMS(tc.body))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package contacts
+
+import (
+	"errors"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"time"
+)
+
+var ErrCustomerIdTaken = errors.New("customer id is already used by another contact")
+
+type Contact struct {
+	Id                int64       `json:"id"`
+	CustomerId        string      `json:"customer_id"`
+	Email             *string     `json:"email"`
+	Phone             *string     `json:"phone"`
+	Whatsapp          *string     `json:"whatsapp"`
+	PushTokens        []string    `json:"push_tokens"`
+	PreferredChannels []string    `json:"preferred_channels"`
+	QuietHours        *QuietHours `json:"quiet_hours"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+}
+
+type CreateContact struct {
+	CustomerId        string      `json:"customer_id" zog:"customer_id"`
+	Email             *string     `json:"email"`
+	Phone             *string     `json:"phone"`
+	Whatsapp          *string     `json:"whatsapp"`
+	PushTokens        []string    `json:"push_tokens" zog:"push_tokens"`
+	PreferredChannels []string    `json:"preferred_channels" zog:"preferred_channels"`
+	QuietHours        *QuietHours `json:"quiet_hours" zog:"quiet_hours"`
+}
+
+type UpdateContact struct {
+	Email             *string     `json:"email"`
+	Phone             *string     `json:"phone"`
+	Whatsapp          *string     `json:"whatsapp"`
+	PushTokens        []string    `json:"push_tokens" zog:"push_tokens"`
+	PreferredChannels []string    `json:"preferred_channels" zog:"preferred_channels"`
+	QuietHours        *QuietHours `json:"quiet_hours" zog:"quiet_hours"`
+}
+
+type ListContactsFilter struct {
+	CustomerId string `zog:"customer_id"`
+}
+
+// QuietHours is the daily period in which the contact does not want to be
+// disturbed, copied to the notifications addressed to it.
+type QuietHours = notifications.QuietHours
+
+// Recipient returns the address of the contact for the notification type, or an
+// empty string when the contact has none. Push notifications go to the first token.
+func (c *Contact) Recipient(notificationType string) string {
+	var recipient *string
+
+	switch notificationType {
+	case "email":
+		recipient = c.Email
+	case "sms":
+		recipient = c.Phone
+	case "whatsapp":
+		recipient = c.Whatsapp
+	case "push":
+		if len(c.PushTokens) > 0 {
+			recipient = &c.PushTokens[0]
+		}
+	}
+
+	if recipient == nil {
+		return ""
+	}
+
+	return *recipient
+}
+
+// PreferredType returns the first preferred channel the contact can be reached
+// on, or an empty string when there is none.
+func (c *Contact) PreferredType() string {
+	for _, channel := range c.PreferredChannels {
+		if c.Recipient(channel) != "" {
+			return channel
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,46 @@
+package contacts
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRecipient(t *testing.T) {
+	email := "maria@example.com"
+	phone := "+5511999999999"
+
+	contact := &Contact{Email: &email, Phone: &phone, PushTokens: []string{"token-1", "token-2"}}
+
+	testCases := []struct {
+		name             string
+		notificationType string
+		expected         string
+	}{
+		{"Should return the e-mail for email notifications", "email", email},
+		{"Should return the phone for sms notifications", "sms", phone},
+		{"Should return the first token for push notifications", "push", "token-1"},
+		{"Should return empty when the contact has no WhatsApp", "whatsapp", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, contact.Recipient(tc.notificationType))
+		})
+	}
+}
+
+func TestPreferredType(t *testing.T) {
+	phone := "+5511999999999"
+
+	t.Run("Should skip preferred channels without a recipient", func(t *testing.T) {
+		contact := &Contact{Phone: &phone, PreferredChannels: []string{"whatsapp", "sms", "email"}}
+
+		assert.Equal(t, "sms", contact.PreferredType())
+	})
+
+	t.Run("Should return empty when no preferred channel can be used", func(t *testing.T) {
+		contact := &Contact{Phone: &phone, PreferredChannels: []string{"email"}}
+
+		assert.Empty(t, contact.PreferredType())
+	})
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"net/http"
+)
+
+var quietHoursSchema = zog.Struct(zog.Schema{
+	"start":    zog.String().Required().Match(notifications.ClockRegex, zog.Message("must be a time such as 22:00")),
+	"end":      zog.String().Required().Match(notifications.ClockRegex, zog.Message("must be a time such as 08:00")),
+	"timezone": zog.String().Required().TestFunc(httputil.IsTimezone, zog.Message("must be an IANA time zone such as America/Sao_Paulo")),
+})
+
+var contactSchema = zog.Struct(zog.Schema{
+	"email":             zog.Ptr(zog.String().Max(255)),
+	"phone":             zog.Ptr(zog.String().Max(32)),
+	"whatsapp":          zog.Ptr(zog.String().Max(32)),
+	"pushTokens":        zog.Slice(zog.String().Max(255)).Max(10),
+	"preferredChannels": zog.Slice(zog.String().OneOf(notifications.Types)),
+	"quietHours":        zog.Ptr(quietHoursSchema),
+})
+
+var createContactSchema = contactSchema.Extend(zog.Schema{
+	"customerId": zog.String().Trim().Required().Max(255),
+})
+
+var errInvalidBody = errors.New("invalid request body")
+
+type CreateHandler struct {
+	contactRepository contacts.Repository
+}
+
+func NewCreateHandler(contactRepository contacts.Repository) *CreateHandler {
+	return &CreateHandler{contactRepository: contactRepository}
+}
+
+func (h *CreateHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var createContact *contacts.CreateContact
+	err := json.NewDecoder(r.Body).Decode(&createContact)
+
+	if err != nil || createContact == nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
+	validationErrors := createContactSchema.Validate(createContact)
+
+	if validationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(validationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	if unprocessableEntityError := normalizeAddresses(createContact.Email, createContact.Phone, createContact.Whatsapp, createContact.PushTokens); unprocessableEntityError != nil {
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	id, err := h.contactRepository.CreateContact(createContact)
+
+	if err != nil {
+		if errors.Is(err, contacts.ErrCustomerIdTaken) {
+			httputil.ConflictResponse(w, err)
+			return
+		}
+
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	contact, err := h.contactRepository.FindContactByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.CreatedResponse(w, contact)
+}
+
+// address is a field of the contact holding the recipient of one notification type.
+type address struct {
+	field            string
+	notificationType string
+	value            *string
+}
+
+// normalizeAddresses validates the addresses of the contact like the recipient of
+// a notification of the matching type, normalizing them in place.
+func normalizeAddresses(email, phone, whatsapp *string, pushTokens []string) *httputil.UnprocessableEntityError {
+	addresses := []address{
+		{"email", "email", email},
+		{"phone", "sms", phone},
+		{"whatsapp", "whatsapp", whatsapp},
+	}
+
+	for i := range pushTokens {
+		addresses = append(addresses, address{"push_tokens", "push", &pushTokens[i]})
+	}
+
+	for _, address := range addresses {
+		if address.value == nil {
+			continue
+		}
+
+		normalized, err := notifications.NormalizeRecipient(address.notificationType, *address.value)
+
+		if err != nil {
+			return &httputil.UnprocessableEntityError{Errors: []string{`The field "` + address.field + `" ` + err.Error()}}
+		}
+
+		*address.value = normalized
+	}
+
+	return nil
+}
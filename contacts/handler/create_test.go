@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuccessCreate(t *testing.T) {
+	t.Run("Should create a contact with normalized addresses", func(t *testing.T) {
+		body := `{"customer_id":"42","email":"Maria@Example.com","phone":"+55 (11) 99999-9999","preferred_channels":["whatsapp","sms"],"quiet_hours":{"start":"22:00","end":"08:00","timezone":"America/Sao_Paulo"}}`
+
+		email := "maria@example.com"
+		phone := "+5511999999999"
+		quietHours := &contacts.QuietHours{Start: "22:00", End: "08:00", Timezone: "America/Sao_Paulo"}
+
+		contact := contacts.Contact{
+			Id:                1,
+			CustomerId:        "42",
+			Email:             &email,
+			Phone:             &phone,
+			PushTokens:        []string{},
+			PreferredChannels: []string{"whatsapp", "sms"},
+			QuietHours:        quietHours,
+			CreatedAt:         time.Now().UTC(),
+			UpdatedAt:         time.Now().UTC(),
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/contacts", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateContact", &contacts.CreateContact{
+			CustomerId:        "42",
+			Email:             &email,
+			Phone:             &phone,
+			PreferredChannels: []string{"whatsapp", "sms"},
+			QuietHours:        quietHours,
+		}).Return(int64(1), nil)
+		repository.On("FindContactByID", int64(1)).Return(&contact, nil)
+
+		NewCreateHandler(repository).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(contact)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestConflictOnCreate(t *testing.T) {
+	t.Run("Should return 409 when the customer id is already used", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/contacts", strings.NewReader(`{"customer_id":"42"}`))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateContact", &contacts.CreateContact{CustomerId: "42"}).Return(int64(0), contacts.ErrCustomerIdTaken)
+
+		NewCreateHandler(repository).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(contacts.ErrCustomerIdTaken))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestInvalidRequestOnCreate(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		body                 string
+		expectedStatusCode   int
+		expectedBodyContains string
+	}{
+		{"Should return 400 when body is invalid", `invalid`, http.StatusBadRequest, errInvalidBody.Error()},
+		{"Should return 422 when customer id is missing", `{"email":"maria@example.com"}`, http.StatusUnprocessableEntity, `\"customer_id\"`},
+		{"Should return 422 when email is invalid", `{"customer_id":"42","email":"maria"}`, http.StatusUnprocessableEntity, `\"email\" must be a valid e-mail address`},
+		{"Should return 422 when phone is invalid", `{"customer_id":"42","phone":"99999-9999"}`, http.StatusUnprocessableEntity, `\"phone\" must be a phone number`},
+		{"Should return 422 when a push token is invalid", `{"customer_id":"42","push_tokens":["abc"]}`, http.StatusUnprocessableEntity, `\"push_tokens\" must be a valid device token`},
+		{"Should return 422 when a preferred channel is invalid", `{"customer_id":"42","preferred_channels":["fax"]}`, http.StatusUnprocessableEntity, `\"preferred_channels`},
+		{"Should return 422 when quiet hours are invalid", `{"customer_id":"42","quiet_hours":{"start":"25:00","end":"08:00","timezone":"America/Sao_Paulo"}}`, http.StatusUnprocessableEntity, `start\" must be a time such as 22:00`},
+		{"Should return 422 when time zone is invalid", `{"customer_id":"42","quiet_hours":{"start":"22:00","end":"08:00","timezone":"Mars/Olympus"}}`, http.StatusUnprocessableEntity, `timezone\" must be an IANA time zone`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/contacts", strings.NewReader(tc.body))
+
+			NewCreateHandler(mocks.NewRepository(t)).
+				Handler(response, request)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
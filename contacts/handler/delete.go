@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"net/http"
+)
+
+type DeleteHandler struct {
+	contactRepository contacts.Repository
+}
+
+func NewDeleteHandler(contactRepository contacts.Repository) *DeleteHandler {
+	return &DeleteHandler{contactRepository: contactRepository}
+}
+
+func (h *DeleteHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	deleted, err := h.contactRepository.DeleteContactByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !deleted {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.NoContentResponse(w)
+}
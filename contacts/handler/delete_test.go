@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDelete(t *testing.T) {
+	testCases := []struct {
+		name               string
+		deleted            bool
+		expectedStatusCode int
+	}{
+		{"Should delete contact successfully", true, http.StatusNoContent},
+		{"Should return 404 when contact not found", false, http.StatusNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("DELETE", "/contacts/1", nil)
+			request.SetPathValue("id", "1")
+
+			repository := mocks.NewRepository(t)
+			repository.On("DeleteContactByID", int64(1)).Return(tc.deleted, nil)
+
+			NewDeleteHandler(repository).
+				Handler(response, request)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"net/http"
+)
+
+var errNotFound = errors.New("contact not found")
+var errInvalidOrMissingId = errors.New("invalid or missing contact id")
+
+type FindHandler struct {
+	contactRepository contacts.Repository
+}
+
+func NewFindHandler(contactRepository contacts.Repository) *FindHandler {
+	return &FindHandler{contactRepository: contactRepository}
+}
+
+func (h *FindHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	contact, err := h.contactRepository.FindContactByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if contact == nil {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.OkResponse(w, contact)
+}
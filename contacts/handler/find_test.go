@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	contact := &contacts.Contact{Id: 1, CustomerId: "42", PushTokens: []string{}, PreferredChannels: []string{}}
+
+	testCases := []struct {
+		name               string
+		contact            *contacts.Contact
+		expectedStatusCode int
+		expectedBody       any
+	}{
+		{"Should find contact successfully", contact, http.StatusOK, contact},
+		{"Should return 404 when contact not found", nil, http.StatusNotFound, httputil.NewErrorMessage(errNotFound)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("GET", "/contacts/1", nil)
+			request.SetPathValue("id", "1")
+
+			repository := mocks.NewRepository(t)
+			repository.On("FindContactByID", int64(1)).Return(tc.contact, nil)
+
+			NewFindHandler(repository).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(tc.expectedBody)
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+}
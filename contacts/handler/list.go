@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/Oudwins/zog"
+	"github.com/Oudwins/zog/zhttp"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"net/http"
+)
+
+var listContactsSchema = zog.Struct(zog.Schema{
+	"customerId": zog.String().Trim().Max(255),
+})
+
+type ListHandler struct {
+	contactRepository contacts.Repository
+}
+
+func NewListHandler(contactRepository contacts.Repository) *ListHandler {
+	return &ListHandler{contactRepository: contactRepository}
+}
+
+func (h *ListHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var filter contacts.ListContactsFilter
+
+	validationErrors := listContactsSchema.Parse(zhttp.Request(r), &filter)
+
+	if validationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(validationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	list, err := h.contactRepository.ListContacts(&filter)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.OkResponse(w, list)
+}
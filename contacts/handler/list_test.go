@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	t.Run("Should list contacts filtered by customer id", func(t *testing.T) {
+		list := []*contacts.Contact{
+			{Id: 1, CustomerId: "42", PushTokens: []string{}, PreferredChannels: []string{}},
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/contacts?customer_id=42", nil)
+
+		repository := mocks.NewRepository(t)
+		repository.On("ListContacts", &contacts.ListContactsFilter{CustomerId: "42"}).Return(list, nil)
+
+		NewListHandler(repository).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(list)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
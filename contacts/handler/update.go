@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"net/http"
+)
+
+type UpdateHandler struct {
+	contactRepository contacts.Repository
+}
+
+func NewUpdateHandler(contactRepository contacts.Repository) *UpdateHandler {
+	return &UpdateHandler{contactRepository: contactRepository}
+}
+
+func (h *UpdateHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	var updateContact *contacts.UpdateContact
+	err := json.NewDecoder(r.Body).Decode(&updateContact)
+
+	if err != nil || updateContact == nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
+	bodyValidationErrors := contactSchema.Validate(updateContact)
+
+	if bodyValidationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(bodyValidationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	if unprocessableEntityError := normalizeAddresses(updateContact.Email, updateContact.Phone, updateContact.Whatsapp, updateContact.PushTokens); unprocessableEntityError != nil {
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	updated, err := h.contactRepository.UpdateContactByID(id, updateContact)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !updated {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	contact, err := h.contactRepository.FindContactByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.OkResponse(w, contact)
+}
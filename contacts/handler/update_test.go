@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUpdate(t *testing.T) {
+	whatsapp := "+5511999999999"
+	updateContact := &contacts.UpdateContact{Whatsapp: &whatsapp, PreferredChannels: []string{"whatsapp"}}
+	contact := &contacts.Contact{Id: 1, CustomerId: "42", Whatsapp: &whatsapp, PreferredChannels: []string{"whatsapp"}}
+
+	testCases := []struct {
+		name               string
+		updated            bool
+		expectedStatusCode int
+		expectedBody       any
+	}{
+		{"Should replace the contact successfully", true, http.StatusOK, contact},
+		{"Should return 404 when contact not found", false, http.StatusNotFound, httputil.NewErrorMessage(errNotFound)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("PUT", "/contacts/1", strings.NewReader(`{"whatsapp":"+55 11 99999-9999","preferred_channels":["whatsapp"]}`))
+			request.SetPathValue("id", "1")
+
+			repository := mocks.NewRepository(t)
+			repository.On("UpdateContactByID", int64(1), updateContact).Return(tc.updated, nil)
+
+			if tc.updated {
+				repository.On("FindContactByID", int64(1)).Return(contact, nil)
+			}
+
+			NewUpdateHandler(repository).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(tc.expectedBody)
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+}
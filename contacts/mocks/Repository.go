@@ -0,0 +1,320 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	contacts "github.com/Tagliatti/magalu-challenge/contacts"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// CreateContact provides a mock function with given fields: createContact
+func (_m *Repository) CreateContact(createContact *contacts.CreateContact) (int64, error) {
+	ret := _m.Called(createContact)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateContact")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*contacts.CreateContact) (int64, error)); ok {
+		return rf(createContact)
+	}
+	if rf, ok := ret.Get(0).(func(*contacts.CreateContact) int64); ok {
+		r0 = rf(createContact)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*contacts.CreateContact) error); ok {
+		r1 = rf(createContact)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_CreateContact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateContact'
+type Repository_CreateContact_Call struct {
+	*mock.Call
+}
+
+// CreateContact is a helper method to define mock.On call
+//   - createContact *contacts.CreateContact
+func (_e *Repository_Expecter) CreateContact(createContact interface{}) *Repository_CreateContact_Call {
+	return &Repository_CreateContact_Call{Call: _e.mock.On("CreateContact", createContact)}
+}
+
+func (_c *Repository_CreateContact_Call) Run(run func(createContact *contacts.CreateContact)) *Repository_CreateContact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contacts.CreateContact))
+	})
+	return _c
+}
+
+func (_c *Repository_CreateContact_Call) Return(_a0 int64, _a1 error) *Repository_CreateContact_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_CreateContact_Call) RunAndReturn(run func(*contacts.CreateContact) (int64, error)) *Repository_CreateContact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteContactByID provides a mock function with given fields: id
+func (_m *Repository) DeleteContactByID(id int64) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteContactByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_DeleteContactByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteContactByID'
+type Repository_DeleteContactByID_Call struct {
+	*mock.Call
+}
+
+// DeleteContactByID is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) DeleteContactByID(id interface{}) *Repository_DeleteContactByID_Call {
+	return &Repository_DeleteContactByID_Call{Call: _e.mock.On("DeleteContactByID", id)}
+}
+
+func (_c *Repository_DeleteContactByID_Call) Run(run func(id int64)) *Repository_DeleteContactByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteContactByID_Call) Return(_a0 bool, _a1 error) *Repository_DeleteContactByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_DeleteContactByID_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_DeleteContactByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindContactByID provides a mock function with given fields: id
+func (_m *Repository) FindContactByID(id int64) (*contacts.Contact, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindContactByID")
+	}
+
+	var r0 *contacts.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*contacts.Contact, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *contacts.Contact); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*contacts.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindContactByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindContactByID'
+type Repository_FindContactByID_Call struct {
+	*mock.Call
+}
+
+// FindContactByID is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) FindContactByID(id interface{}) *Repository_FindContactByID_Call {
+	return &Repository_FindContactByID_Call{Call: _e.mock.On("FindContactByID", id)}
+}
+
+func (_c *Repository_FindContactByID_Call) Run(run func(id int64)) *Repository_FindContactByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_FindContactByID_Call) Return(_a0 *contacts.Contact, _a1 error) *Repository_FindContactByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindContactByID_Call) RunAndReturn(run func(int64) (*contacts.Contact, error)) *Repository_FindContactByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListContacts provides a mock function with given fields: filter
+func (_m *Repository) ListContacts(filter *contacts.ListContactsFilter) ([]*contacts.Contact, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListContacts")
+	}
+
+	var r0 []*contacts.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*contacts.ListContactsFilter) ([]*contacts.Contact, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*contacts.ListContactsFilter) []*contacts.Contact); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*contacts.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*contacts.ListContactsFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListContacts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListContacts'
+type Repository_ListContacts_Call struct {
+	*mock.Call
+}
+
+// ListContacts is a helper method to define mock.On call
+//   - filter *contacts.ListContactsFilter
+func (_e *Repository_Expecter) ListContacts(filter interface{}) *Repository_ListContacts_Call {
+	return &Repository_ListContacts_Call{Call: _e.mock.On("ListContacts", filter)}
+}
+
+func (_c *Repository_ListContacts_Call) Run(run func(filter *contacts.ListContactsFilter)) *Repository_ListContacts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*contacts.ListContactsFilter))
+	})
+	return _c
+}
+
+func (_c *Repository_ListContacts_Call) Return(_a0 []*contacts.Contact, _a1 error) *Repository_ListContacts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListContacts_Call) RunAndReturn(run func(*contacts.ListContactsFilter) ([]*contacts.Contact, error)) *Repository_ListContacts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateContactByID provides a mock function with given fields: id, updateContact
+func (_m *Repository) UpdateContactByID(id int64, updateContact *contacts.UpdateContact) (bool, error) {
+	ret := _m.Called(id, updateContact)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateContactByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, *contacts.UpdateContact) (bool, error)); ok {
+		return rf(id, updateContact)
+	}
+	if rf, ok := ret.Get(0).(func(int64, *contacts.UpdateContact) bool); ok {
+		r0 = rf(id, updateContact)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, *contacts.UpdateContact) error); ok {
+		r1 = rf(id, updateContact)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UpdateContactByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateContactByID'
+type Repository_UpdateContactByID_Call struct {
+	*mock.Call
+}
+
+// UpdateContactByID is a helper method to define mock.On call
+//   - id int64
+//   - updateContact *contacts.UpdateContact
+func (_e *Repository_Expecter) UpdateContactByID(id interface{}, updateContact interface{}) *Repository_UpdateContactByID_Call {
+	return &Repository_UpdateContactByID_Call{Call: _e.mock.On("UpdateContactByID", id, updateContact)}
+}
+
+func (_c *Repository_UpdateContactByID_Call) Run(run func(id int64, updateContact *contacts.UpdateContact)) *Repository_UpdateContactByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(*contacts.UpdateContact))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateContactByID_Call) Return(_a0 bool, _a1 error) *Repository_UpdateContactByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_UpdateContactByID_Call) RunAndReturn(run func(int64, *contacts.UpdateContact) (bool, error)) *Repository_UpdateContactByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
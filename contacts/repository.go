@@ -0,0 +1,186 @@
+package contacts
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+)
+
+type Repository interface {
+	CreateContact(createContact *CreateContact) (int64, error)
+	UpdateContactByID(id int64, updateContact *UpdateContact) (bool, error)
+	FindContactByID(id int64) (*Contact, error)
+	ListContacts(filter *ListContactsFilter) ([]*Contact, error)
+	DeleteContactByID(id int64) (bool, error)
+}
+
+const contactColumns = `id, customer_id, email, phone, whatsapp, push_tokens, preferred_channels, quiet_hours, created_at, updated_at`
+
+const uniqueViolation = "23505"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) CreateContact(createContact *CreateContact) (int64, error) {
+	var id int64
+
+	err := r.db.QueryRow(`
+		INSERT INTO contacts (customer_id, email, phone, whatsapp, push_tokens, preferred_channels, quiet_hours)
+		VALUES ($1, $2, $3, $4, $5, $6::notification_type[], $7)
+		RETURNING id`,
+		createContact.CustomerId,
+		createContact.Email,
+		createContact.Phone,
+		createContact.Whatsapp,
+		pq.Array(orEmpty(createContact.PushTokens)),
+		pq.Array(orEmpty(createContact.PreferredChannels)),
+		createContact.QuietHours,
+	).Scan(&id)
+
+	if err != nil {
+		var pqErr *pq.Error
+
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return 0, ErrCustomerIdTaken
+		}
+
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// UpdateContactByID replaces every field of the contact but its customer id.
+func (r *PostgresRepository) UpdateContactByID(id int64, updateContact *UpdateContact) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE contacts
+		SET email = $2, phone = $3, whatsapp = $4, push_tokens = $5, preferred_channels = $6::notification_type[], quiet_hours = $7, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1`,
+		id,
+		updateContact.Email,
+		updateContact.Phone,
+		updateContact.Whatsapp,
+		pq.Array(orEmpty(updateContact.PushTokens)),
+		pq.Array(orEmpty(updateContact.PreferredChannels)),
+		updateContact.QuietHours,
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *PostgresRepository) FindContactByID(id int64) (*Contact, error) {
+	row := r.db.QueryRow(`SELECT `+contactColumns+` FROM contacts WHERE id = $1`, id)
+	contact, err := scanContact(row)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return contact, nil
+}
+
+func (r *PostgresRepository) ListContacts(filter *ListContactsFilter) ([]*Contact, error) {
+	query := `SELECT ` + contactColumns + ` FROM contacts`
+	args := make([]any, 0)
+
+	if filter.CustomerId != "" {
+		query += ` WHERE customer_id = $1`
+		args = append(args, filter.CustomerId)
+	}
+
+	rows, err := r.db.Query(query+` ORDER BY id`, args...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	contacts := make([]*Contact, 0)
+
+	for rows.Next() {
+		contact, err := scanContact(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		contacts = append(contacts, contact)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
+// DeleteContactByID removes the contact. Notifications created for it keep their
+// resolved recipient.
+func (r *PostgresRepository) DeleteContactByID(id int64) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM contacts WHERE id = $1`, id)
+
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func scanContact(row rowScanner) (*Contact, error) {
+	var contact Contact
+	err := row.Scan(
+		&contact.Id,
+		&contact.CustomerId,
+		&contact.Email,
+		&contact.Phone,
+		&contact.Whatsapp,
+		pq.Array(&contact.PushTokens),
+		pq.Array(&contact.PreferredChannels),
+		&contact.QuietHours,
+		&contact.CreatedAt,
+		&contact.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &contact, nil
+}
+
+func orEmpty(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
@@ -0,0 +1,127 @@
+package contacts
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Tagliatti/magalu-challenge/database"
+	"github.com/Tagliatti/magalu-challenge/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PostgresRepositoryTestSuite struct {
+	suite.Suite
+	pgContainer *testhelpers.PostgresContainer
+	repository  *PostgresRepository
+	db          *sql.DB
+	ctx         context.Context
+}
+
+func (suite *PostgresRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	pgContainer, err := testhelpers.NewPostgresContainer(suite.ctx)
+	require.Nil(suite.T(), err, "failed to start postgres container: %v", err)
+
+	suite.pgContainer = pgContainer
+
+	db, err := database.ConnectTest(pgContainer.ConnectionString)
+	require.Nil(suite.T(), err, "failed to connect to database: %v", err)
+
+	suite.db = db
+	suite.repository = NewPostgresRepository(db)
+}
+
+func (suite *PostgresRepositoryTestSuite) TearDownSuite() {
+	if err := suite.pgContainer.Terminate(suite.ctx); err != nil {
+		suite.T().Fatalf("failed to terminate pgContainer: %s", err)
+	}
+}
+
+func TestPostgresRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PostgresRepositoryTestSuite))
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessCreateContact() {
+	t := suite.T()
+
+	t.Run("Should create contact successfully", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		email := "maria@example.com"
+		quietHours := &QuietHours{Start: "22:00", End: "08:00", Timezone: "America/Sao_Paulo"}
+
+		id, err := suite.repository.CreateContact(&CreateContact{
+			CustomerId:        "42",
+			Email:             &email,
+			PushTokens:        []string{"token"},
+			PreferredChannels: []string{"push", "email"},
+			QuietHours:        quietHours,
+		})
+		require.Nilf(t, err, "failed to create contact: %v", err)
+
+		contact, err := suite.repository.FindContactByID(id)
+		require.Nilf(t, err, "failed to find contact: %v", err)
+		require.NotNil(t, contact)
+
+		assert.Equal(t, "42", contact.CustomerId)
+		assert.Equal(t, &email, contact.Email)
+		assert.Nil(t, contact.Phone)
+		assert.Equal(t, []string{"token"}, contact.PushTokens)
+		assert.Equal(t, []string{"push", "email"}, contact.PreferredChannels)
+		assert.Equal(t, quietHours, contact.QuietHours)
+	})
+
+	t.Run("Should return error when the customer id is already used", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		_, err = suite.repository.CreateContact(&CreateContact{CustomerId: "42"})
+		require.Nilf(t, err, "failed to create contact: %v", err)
+
+		_, err = suite.repository.CreateContact(&CreateContact{CustomerId: "42"})
+
+		assert.ErrorIs(t, err, ErrCustomerIdTaken)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestUpdateContactByID() {
+	t := suite.T()
+
+	t.Run("Should replace the contact fields", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		email := "maria@example.com"
+
+		id, err := suite.repository.CreateContact(&CreateContact{CustomerId: "42", Email: &email})
+		require.Nilf(t, err, "failed to create contact: %v", err)
+
+		phone := "+5511999999999"
+
+		updated, err := suite.repository.UpdateContactByID(id, &UpdateContact{Phone: &phone, PreferredChannels: []string{"sms"}})
+		require.Nilf(t, err, "failed to update contact: %v", err)
+		assert.True(t, updated)
+
+		contact, err := suite.repository.FindContactByID(id)
+		require.Nilf(t, err, "failed to find contact: %v", err)
+
+		assert.Nil(t, contact.Email)
+		assert.Equal(t, &phone, contact.Phone)
+		assert.Equal(t, []string{"sms"}, contact.PreferredChannels)
+		assert.Nil(t, contact.QuietHours)
+	})
+
+	t.Run("Should return false when contact not found", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		updated, err := suite.repository.UpdateContactByID(1, &UpdateContact{})
+		require.Nilf(t, err, "failed to update contact: %v", err)
+
+		assert.False(t, updated)
+	})
+}
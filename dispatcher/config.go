@@ -0,0 +1,143 @@
+package dispatcher
+
+import (
+	"fmt"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultBatchSize    = 100
+	defaultClaimLease   = 5 * time.Minute
+)
+
+var defaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   30 * time.Second,
+	MaxDelay:    time.Hour,
+	Jitter:      0.2,
+}
+
+type Config struct {
+	PollInterval  time.Duration
+	BatchSize     int
+	ClaimLease    time.Duration
+	RetryPolicies map[string]RetryPolicy
+}
+
+func (c *Config) RetryPolicy(notificationType string) RetryPolicy {
+	if policy, ok := c.RetryPolicies[notificationType]; ok {
+		return policy
+	}
+
+	return defaultRetryPolicy
+}
+
+func ConfigFromEnv() (*Config, error) {
+	config := &Config{
+		PollInterval:  defaultPollInterval,
+		BatchSize:     defaultBatchSize,
+		ClaimLease:    defaultClaimLease,
+		RetryPolicies: make(map[string]RetryPolicy),
+	}
+
+	if value := os.Getenv("DISPATCHER_POLL_INTERVAL"); value != "" {
+		pollInterval, err := time.ParseDuration(value)
+
+		if err != nil || pollInterval <= 0 {
+			return nil, fmt.Errorf("invalid DISPATCHER_POLL_INTERVAL %q", value)
+		}
+
+		config.PollInterval = pollInterval
+	}
+
+	if value := os.Getenv("DISPATCHER_BATCH_SIZE"); value != "" {
+		batchSize, err := strconv.Atoi(value)
+
+		if err != nil || batchSize <= 0 {
+			return nil, fmt.Errorf("invalid DISPATCHER_BATCH_SIZE %q", value)
+		}
+
+		config.BatchSize = batchSize
+	}
+
+	if value := os.Getenv("DISPATCHER_CLAIM_LEASE"); value != "" {
+		claimLease, err := time.ParseDuration(value)
+
+		if err != nil || claimLease <= 0 {
+			return nil, fmt.Errorf("invalid DISPATCHER_CLAIM_LEASE %q", value)
+		}
+
+		config.ClaimLease = claimLease
+	}
+
+	basePolicy, err := retryPolicyFromEnv("RETRY_", defaultRetryPolicy)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, notificationType := range notifications.Types {
+		policy, err := retryPolicyFromEnv("RETRY_"+strings.ToUpper(notificationType)+"_", basePolicy)
+
+		if err != nil {
+			return nil, err
+		}
+
+		config.RetryPolicies[notificationType] = policy
+	}
+
+	return config, nil
+}
+
+// retryPolicyFromEnv reads <prefix>MAX_ATTEMPTS, <prefix>BASE_DELAY, <prefix>MAX_DELAY
+// and <prefix>JITTER, keeping the fallback value for any variable that is not set.
+func retryPolicyFromEnv(prefix string, fallback RetryPolicy) (RetryPolicy, error) {
+	policy := fallback
+
+	if value := os.Getenv(prefix + "MAX_ATTEMPTS"); value != "" {
+		maxAttempts, err := strconv.Atoi(value)
+
+		if err != nil || maxAttempts <= 0 {
+			return policy, fmt.Errorf("invalid %sMAX_ATTEMPTS %q", prefix, value)
+		}
+
+		policy.MaxAttempts = maxAttempts
+	}
+
+	if value := os.Getenv(prefix + "BASE_DELAY"); value != "" {
+		baseDelay, err := time.ParseDuration(value)
+
+		if err != nil || baseDelay <= 0 {
+			return policy, fmt.Errorf("invalid %sBASE_DELAY %q", prefix, value)
+		}
+
+		policy.BaseDelay = baseDelay
+	}
+
+	if value := os.Getenv(prefix + "MAX_DELAY"); value != "" {
+		maxDelay, err := time.ParseDuration(value)
+
+		if err != nil || maxDelay <= 0 {
+			return policy, fmt.Errorf("invalid %sMAX_DELAY %q", prefix, value)
+		}
+
+		policy.MaxDelay = maxDelay
+	}
+
+	if value := os.Getenv(prefix + "JITTER"); value != "" {
+		jitter, err := strconv.ParseFloat(value, 64)
+
+		if err != nil || jitter < 0 || jitter > 1 {
+			return policy, fmt.Errorf("invalid %sJITTER %q", prefix, value)
+		}
+
+		policy.Jitter = jitter
+	}
+
+	return policy, nil
+}
@@ -0,0 +1,205 @@
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"github.com/Tagliatti/magalu-challenge/channels"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"log"
+	"time"
+)
+
+type Sender interface {
+	Send(ctx context.Context, message *channels.Message) error
+}
+
+type Dispatcher struct {
+	notificationRepository notifications.Repository
+	templateRepository     templates.Repository
+	sender                 Sender
+	config                 *Config
+}
+
+func NewDispatcher(notificationRepository notifications.Repository, templateRepository templates.Repository, sender Sender, config *Config) *Dispatcher {
+	return &Dispatcher{
+		notificationRepository: notificationRepository,
+		templateRepository:     templateRepository,
+		sender:                 sender,
+		config:                 config,
+	}
+}
+
+// Run polls for due notifications until ctx is canceled, expiring the ones
+// whose expiry passed before they could be sent. The batch in progress
+// when ctx is canceled is not interrupted mid-send; unsent notifications are
+// released so another replica can pick them up right away.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.ExpireDue(); err != nil {
+			log.Printf("Erro ao expirar notificações: %v", err)
+		}
+
+		if _, err := d.DispatchDue(ctx); err != nil {
+			log.Printf("Erro ao despachar notificações: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExpireDue moves the notifications not sent before their expiry to expired,
+// returning how many expired.
+func (d *Dispatcher) ExpireDue() (int64, error) {
+	return d.notificationRepository.ExpireNotifications(d.config.ClaimLease)
+}
+
+// DispatchDue sends the due notifications, returning how many were sent. The
+// lease of the batch starts when it is claimed, so every send must finish within
+// it: once too little of it remains, the rest of the batch is released instead
+// of being sent after another replica may have claimed it again.
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	claimedAt := time.Now()
+	claimed, err := d.notificationRepository.ClaimDueNotifications(d.config.BatchSize, d.config.ClaimLease)
+
+	if err != nil {
+		return 0, err
+	}
+
+	// A tenth of the lease is kept to record the result of the last send.
+	margin := d.config.ClaimLease / 10
+	deadline := claimedAt.Add(d.config.ClaimLease - margin)
+	sent := 0
+
+	for i, notification := range claimed {
+		if ctx.Err() != nil || time.Until(deadline) < margin {
+			d.release(claimed[i:])
+			break
+		}
+
+		if d.dispatch(ctx, notification, deadline) {
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, notification *notifications.Notification, deadline time.Time) bool {
+	// The notification may expire while the ones claimed before it are sent.
+	if notification.IsExpired(time.Now()) {
+		if _, err := d.notificationRepository.UpdateNotificationAsExpired(notification.Id); err != nil {
+			log.Printf("Erro ao marcar notificação %d como expirada: %v", notification.Id, err)
+		}
+
+		return false
+	}
+
+	sendCtx, cancel := context.WithDeadline(context.WithoutCancel(ctx), deadline)
+	defer cancel()
+
+	message, err := d.newMessage(notification)
+
+	if err != nil {
+		log.Printf("Erro ao renderizar notificação %d: %v", notification.Id, err)
+		d.fail(notification, err)
+		return false
+	}
+
+	if err := d.sender.Send(sendCtx, message); err != nil {
+		log.Printf("Erro ao enviar notificação %d: %v", notification.Id, err)
+		d.fail(notification, err)
+		return false
+	}
+
+	if _, err := d.notificationRepository.UpdateNotificationAsSent(notification.Id, notification.Type); err != nil {
+		log.Printf("Erro ao marcar notificação %d como enviada: %v", notification.Id, err)
+		return false
+	}
+
+	return true
+}
+
+// newMessage renders the template version the notification was created with,
+// when it references one.
+func (d *Dispatcher) newMessage(notification *notifications.Notification) (*channels.Message, error) {
+	message := channels.NewMessage(notification)
+
+	if notification.TemplateId == nil || notification.TemplateVersion == nil {
+		return message, nil
+	}
+
+	template, err := d.templateRepository.FindTemplateVersion(*notification.TemplateId, *notification.TemplateVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if template == nil {
+		return nil, fmt.Errorf("template %d version %d not found", *notification.TemplateId, *notification.TemplateVersion)
+	}
+
+	locale := ""
+
+	if notification.Locale != nil {
+		locale = *notification.Locale
+	}
+
+	rendered, err := template.Localized(locale).Render(notification.Variables)
+
+	if err != nil {
+		return nil, err
+	}
+
+	message.Subject = rendered.Subject
+	message.Body = rendered.Body
+	message.HtmlBody = rendered.HtmlBody
+
+	return message, nil
+}
+
+// fail schedules another attempt according to the retry policy of the notification
+// type. Once its attempts are exhausted, the notification falls back to the next
+// channel of its chain, or moves to failed when there is none. Both are delayed
+// to its send window and quiet hours.
+func (d *Dispatcher) fail(notification *notifications.Notification, sendErr error) {
+	attempt := notification.Attempts + 1
+	policy := d.config.RetryPolicy(notification.Type)
+
+	if attempt < policy.MaxAttempts {
+		nextAttemptAt := notification.NextSendTime(time.Now().Add(policy.Backoff(attempt)))
+
+		if _, err := d.notificationRepository.UpdateNotificationForRetry(notification.Id, nextAttemptAt, sendErr.Error()); err != nil {
+			log.Printf("Erro ao reagendar notificação %d: %v", notification.Id, err)
+		}
+
+		return
+	}
+
+	if position, ok := notification.Channels.Next(notification.ChannelPosition); ok {
+		if _, err := d.notificationRepository.UpdateNotificationForFallback(notification.Id, position, notification.Channels[position], notification.NextSendTime(time.Now()), sendErr.Error()); err != nil {
+			log.Printf("Erro ao redirecionar notificação %d para outro canal: %v", notification.Id, err)
+		}
+
+		return
+	}
+
+	if _, err := d.notificationRepository.UpdateNotificationAsFailed(notification.Id, sendErr.Error()); err != nil {
+		log.Printf("Erro ao marcar notificação %d como falha: %v", notification.Id, err)
+	}
+}
+
+func (d *Dispatcher) release(pending []*notifications.Notification) {
+	for _, notification := range pending {
+		if _, err := d.notificationRepository.ReleaseNotification(notification.Id); err != nil {
+			log.Printf("Erro ao liberar notificação %d: %v", notification.Id, err)
+		}
+	}
+}
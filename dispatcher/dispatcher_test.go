@@ -0,0 +1,422 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"github.com/Tagliatti/magalu-challenge/channels"
+	"github.com/Tagliatti/magalu-challenge/dispatcher/mocks"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	notificationMocks "github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	templateMocks "github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var testConfig = &Config{
+	PollInterval: time.Second,
+	BatchSize:    10,
+	ClaimLease:   time.Minute,
+	RetryPolicies: map[string]RetryPolicy{
+		"sms":  {MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute},
+		"push": {MaxAttempts: 1, BaseDelay: time.Second, MaxDelay: time.Minute},
+	},
+}
+
+func TestSuccessDispatchDue(t *testing.T) {
+	t.Run("Should send claimed notifications and mark them as sent", func(t *testing.T) {
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "sms", Recipient: "1234567890", Message: "Hello"},
+			{Id: 2, Type: "email", Recipient: "test@example.com", Message: "Hello"},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationAsSent", int64(1), "sms").Return(true, nil)
+		repository.On("UpdateNotificationAsSent", int64(2), "email").Return(true, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.Anything, channels.NewMessage(claimed[0])).Return(nil)
+		sender.On("Send", mock.Anything, channels.NewMessage(claimed[1])).Return(nil)
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Equal(t, 2, sent)
+	})
+}
+
+func TestTemplateOnDispatchDue(t *testing.T) {
+	templateId := int64(7)
+	templateVersion := 2
+	subject := "Pedido {{.order}}"
+	htmlBody := "<p>Olá, {{.name}}</p>"
+
+	template := &templates.Template{
+		Id:        templateId,
+		Version:   templateVersion,
+		Subject:   &subject,
+		Body:      "Olá, {{.name}}",
+		HtmlBody:  &htmlBody,
+		Variables: []string{"name", "order"},
+	}
+
+	t.Run("Should render the template version of the notification", func(t *testing.T) {
+		claimed := []*notifications.Notification{
+			{
+				Id:              1,
+				Type:            "email",
+				Recipient:       "test@example.com",
+				TemplateId:      &templateId,
+				TemplateVersion: &templateVersion,
+				Variables:       notifications.Variables{"name": "<Maria>", "order": "42"},
+			},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationAsSent", int64(1), "email").Return(true, nil)
+
+		templateRepository := templateMocks.NewRepository(t)
+		templateRepository.On("FindTemplateVersion", templateId, templateVersion).Return(template, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.Anything, &channels.Message{
+			NotificationID: 1,
+			Type:           "email",
+			Recipient:      "test@example.com",
+			Subject:        "Pedido 42",
+			Body:           "Olá, <Maria>",
+			HtmlBody:       "<p>Olá, &lt;Maria&gt;</p>",
+		}).Return(nil)
+
+		sent, err := NewDispatcher(repository, templateRepository, sender, testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("Should render the variant of the notification locale", func(t *testing.T) {
+		locale := "es-MX"
+		localized := *template
+		localized.Locales = templates.Locales{"es": {Body: "Hola, {{.name}}"}}
+
+		claimed := []*notifications.Notification{
+			{
+				Id:              1,
+				Type:            "sms",
+				Recipient:       "+5511999999999",
+				TemplateId:      &templateId,
+				TemplateVersion: &templateVersion,
+				Variables:       notifications.Variables{"name": "Maria", "order": "42"},
+				Locale:          &locale,
+			},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationAsSent", int64(1), "sms").Return(true, nil)
+
+		templateRepository := templateMocks.NewRepository(t)
+		templateRepository.On("FindTemplateVersion", templateId, templateVersion).Return(&localized, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.Anything, &channels.Message{
+			NotificationID: 1,
+			Type:           "sms",
+			Recipient:      "+5511999999999",
+			Body:           "Hola, Maria",
+		}).Return(nil)
+
+		sent, err := NewDispatcher(repository, templateRepository, sender, testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Equal(t, 1, sent)
+	})
+
+	t.Run("Should schedule a retry when the template version is missing", func(t *testing.T) {
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "sms", Recipient: "+5511999999999", TemplateId: &templateId, TemplateVersion: &templateVersion},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationForRetry", int64(1), mock.Anything, "template 7 version 2 not found").Return(true, nil)
+
+		templateRepository := templateMocks.NewRepository(t)
+		templateRepository.On("FindTemplateVersion", templateId, templateVersion).Return(nil, nil)
+
+		sent, err := NewDispatcher(repository, templateRepository, mocks.NewSender(t), testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Equal(t, 0, sent)
+	})
+}
+
+func TestSendErrorOnDispatchDue(t *testing.T) {
+	t.Run("Should schedule another attempt when sender fails", func(t *testing.T) {
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "sms", Recipient: "1234567890", Message: "Hello", Attempts: 0},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationForRetry", int64(1), mock.AnythingOfType("time.Time"), "provider unavailable").Return(true, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.Anything, channels.NewMessage(claimed[0])).Return(errors.New("provider unavailable"))
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("Should mark notification as failed when attempts are exhausted", func(t *testing.T) {
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "sms", Recipient: "1234567890", Message: "Hello", Attempts: 2},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationAsFailed", int64(1), "provider unavailable").Return(true, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.Anything, channels.NewMessage(claimed[0])).Return(errors.New("provider unavailable"))
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Zero(t, sent)
+	})
+}
+
+func TestSendWindowOnDispatchDue(t *testing.T) {
+	t.Run("Should schedule the retry inside the send window of the notification", func(t *testing.T) {
+		timezone := "UTC"
+		sendWindow := &notifications.SendWindow{Start: "03:00", End: "03:01"}
+
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "sms", Recipient: "1234567890", Message: "Hello", SendWindow: sendWindow, Timezone: &timezone},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationForRetry", int64(1), mock.MatchedBy(func(nextAttemptAt time.Time) bool {
+			return nextAttemptAt.Hour() == 3 && nextAttemptAt.Minute() == 0
+		}), "provider unavailable").Return(true, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.Anything, channels.NewMessage(claimed[0])).Return(errors.New("provider unavailable"))
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("Should schedule the retry outside the quiet hours of the notification", func(t *testing.T) {
+		quietHours := &notifications.QuietHours{Start: "03:01", End: "03:00", Timezone: "UTC"}
+
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "sms", Recipient: "1234567890", Message: "Hello", QuietHours: quietHours},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationForRetry", int64(1), mock.MatchedBy(func(nextAttemptAt time.Time) bool {
+			return nextAttemptAt.Hour() == 3 && nextAttemptAt.Minute() == 0
+		}), "provider unavailable").Return(true, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.Anything, channels.NewMessage(claimed[0])).Return(errors.New("provider unavailable"))
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("Should delay the fallback to the send window of the notification", func(t *testing.T) {
+		timezone := "UTC"
+		sendWindow := &notifications.SendWindow{Start: "03:00", End: "03:01"}
+		chain := notifications.Channels{
+			{Type: "push", Recipient: "device-token"},
+			{Type: "sms", Recipient: "+5511999999999"},
+		}
+
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "push", Recipient: "device-token", Message: "Hello", Channels: chain, SendWindow: sendWindow, Timezone: &timezone},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationForFallback", int64(1), 1, chain[1], mock.MatchedBy(func(nextAttemptAt time.Time) bool {
+			return nextAttemptAt.Hour() == 3 && nextAttemptAt.Minute() == 0
+		}), "provider rejected").Return(true, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.Anything, channels.NewMessage(claimed[0])).Return(errors.New("provider rejected"))
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Zero(t, sent)
+	})
+}
+
+func TestExpirationOnDispatchDue(t *testing.T) {
+	t.Run("Should expire a claimed notification instead of sending it once its expiry passed", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Second)
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "sms", Recipient: "1234567890", Message: "Your code is 123456", ExpiresAt: &expiresAt},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationAsExpired", int64(1)).Return(true, nil)
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), mocks.NewSender(t), testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("Should expire the notifications not sent before their expiry", func(t *testing.T) {
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ExpireNotifications", testConfig.ClaimLease).Return(int64(2), nil)
+
+		expired, err := NewDispatcher(repository, templateMocks.NewRepository(t), mocks.NewSender(t), testConfig).
+			ExpireDue()
+
+		require.Nilf(t, err, "failed to expire: %v", err)
+		assert.Equal(t, int64(2), expired)
+	})
+}
+
+func TestFallbackOnDispatchDue(t *testing.T) {
+	chain := notifications.Channels{
+		{Type: "push", Recipient: "device-token"},
+		{Type: "whatsapp", Recipient: ""},
+		{Type: "sms", Recipient: "+5511999999999"},
+	}
+
+	t.Run("Should fall back to the next channel with a recipient when attempts are exhausted", func(t *testing.T) {
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "push", Recipient: "device-token", Message: "Hello", Channels: chain},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationForFallback", int64(1), 2, chain[2], mock.AnythingOfType("time.Time"), "provider rejected").Return(true, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.Anything, channels.NewMessage(claimed[0])).Return(errors.New("provider rejected"))
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Zero(t, sent)
+	})
+
+	t.Run("Should mark notification as failed when the chain is exhausted", func(t *testing.T) {
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "sms", Recipient: "+5511999999999", Message: "Hello", Attempts: 2, Channels: chain, ChannelPosition: 2},
+		}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationAsFailed", int64(1), "provider unavailable").Return(true, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.Anything, channels.NewMessage(claimed[0])).Return(errors.New("provider unavailable"))
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, testConfig).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Zero(t, sent)
+	})
+}
+
+func TestCanceledContextOnDispatchDue(t *testing.T) {
+	t.Run("Should release claimed notifications when context is canceled", func(t *testing.T) {
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "sms", Recipient: "1234567890", Message: "Hello"},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(claimed, nil)
+		repository.On("ReleaseNotification", int64(1)).Return(true, nil)
+
+		sender := mocks.NewSender(t)
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, testConfig).
+			DispatchDue(ctx)
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Zero(t, sent)
+	})
+}
+
+func TestLeaseOnDispatchDue(t *testing.T) {
+	t.Run("Should release the rest of the batch when the lease is running out", func(t *testing.T) {
+		claimed := []*notifications.Notification{
+			{Id: 1, Type: "sms", Recipient: "1234567890", Message: "Hello"},
+			{Id: 2, Type: "sms", Recipient: "1234567890", Message: "Hello"},
+		}
+		config := &Config{BatchSize: 10, ClaimLease: 100 * time.Millisecond}
+
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", config.BatchSize, config.ClaimLease).Return(claimed, nil)
+		repository.On("UpdateNotificationAsSent", int64(1), "sms").Return(true, nil)
+		repository.On("ReleaseNotification", int64(2)).Return(true, nil)
+
+		sender := mocks.NewSender(t)
+		sender.On("Send", mock.MatchedBy(func(ctx context.Context) bool {
+			deadline, ok := ctx.Deadline()
+
+			return ok && time.Until(deadline) <= config.ClaimLease
+		}), channels.NewMessage(claimed[0])).Run(func(args mock.Arguments) {
+			time.Sleep(85 * time.Millisecond)
+		}).Return(nil).Once()
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, config).
+			DispatchDue(context.Background())
+
+		require.Nilf(t, err, "failed to dispatch: %v", err)
+		assert.Equal(t, 1, sent)
+	})
+}
+
+func TestClaimErrorOnDispatchDue(t *testing.T) {
+	t.Run("Should return error when claiming fails", func(t *testing.T) {
+		repository := notificationMocks.NewRepository(t)
+		repository.On("ClaimDueNotifications", testConfig.BatchSize, testConfig.ClaimLease).Return(nil, errors.New("connection refused"))
+
+		sender := mocks.NewSender(t)
+
+		sent, err := NewDispatcher(repository, templateMocks.NewRepository(t), sender, testConfig).
+			DispatchDue(context.Background())
+
+		assert.NotNil(t, err)
+		assert.Zero(t, sent)
+	})
+}
@@ -0,0 +1,65 @@
+package dispatcher
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	testCases := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{9, 10 * time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run("Should double the delay on every attempt up to the max delay (attempt "+strconv.Itoa(tc.attempt)+")", func(t *testing.T) {
+			assert.Equal(t, tc.expected, policy.Backoff(tc.attempt))
+		})
+	}
+
+	t.Run("Should keep the delay within the jitter range", func(t *testing.T) {
+		policy := RetryPolicy{MaxAttempts: 10, BaseDelay: 10 * time.Second, MaxDelay: time.Minute, Jitter: 0.5}
+
+		for i := 0; i < 100; i++ {
+			delay := policy.Backoff(1)
+
+			assert.GreaterOrEqual(t, delay, 5*time.Second)
+			assert.LessOrEqual(t, delay, 15*time.Second)
+		}
+	})
+}
+
+func TestRetryPolicyFromEnv(t *testing.T) {
+	t.Run("Should override the default policy per notification type", func(t *testing.T) {
+		t.Setenv("RETRY_MAX_ATTEMPTS", "3")
+		t.Setenv("RETRY_SMS_MAX_ATTEMPTS", "7")
+		t.Setenv("RETRY_SMS_BASE_DELAY", "1m")
+
+		config, err := ConfigFromEnv()
+
+		assert.Nil(t, err)
+		assert.Equal(t, 7, config.RetryPolicy("sms").MaxAttempts)
+		assert.Equal(t, time.Minute, config.RetryPolicy("sms").BaseDelay)
+		assert.Equal(t, 3, config.RetryPolicy("email").MaxAttempts)
+		assert.Equal(t, defaultRetryPolicy.BaseDelay, config.RetryPolicy("email").BaseDelay)
+	})
+
+	t.Run("Should return error when jitter is out of range", func(t *testing.T) {
+		t.Setenv("RETRY_PUSH_JITTER", "2")
+
+		_, err := ConfigFromEnv()
+
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,26 @@
+package httputil
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var errUnauthorized = errors.New("missing or invalid admin token")
+
+// RequireToken only lets requests carrying "Authorization: Bearer <token>" reach
+// next. An empty token rejects every request, so admin routes stay closed
+// unless explicitly configured.
+func RequireToken(token string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			UnauthorizedResponse(w, errUnauthorized)
+			return
+		}
+
+		next(w, r)
+	}
+}
@@ -0,0 +1,37 @@
+package httputil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequireToken(t *testing.T) {
+	testCases := []struct {
+		name               string
+		token              string
+		authorization      string
+		expectedStatusCode int
+	}{
+		{"Should allow request with valid token", "secret", "Bearer secret", http.StatusNoContent},
+		{"Should reject request with invalid token", "secret", "Bearer invalid", http.StatusUnauthorized},
+		{"Should reject request without token", "secret", "", http.StatusUnauthorized},
+		{"Should reject every request when no token is configured", "", "Bearer ", http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("DELETE", "/admin/notifications/1", nil)
+			request.Header.Set("Authorization", tc.authorization)
+
+			RequireToken(tc.token, func(w http.ResponseWriter, r *http.Request) {
+				NoContentResponse(w)
+			})(response, request)
+
+			if response.Code != tc.expectedStatusCode {
+				t.Errorf("Expected status code %d, got %d", tc.expectedStatusCode, response.Code)
+			}
+		})
+	}
+}
@@ -73,6 +73,24 @@ func NotFoundResponse(w http.ResponseWriter, err error) {
 	json.NewEncoder(w).Encode(&ErrorMessage{err.Error()})
 }
 
+func UnauthorizedResponse(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusUnauthorized)
+	json.NewEncoder(w).Encode(&ErrorMessage{err.Error()})
+}
+
+func ConflictResponse(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusConflict)
+	json.NewEncoder(w).Encode(&ErrorMessage{err.Error()})
+}
+
+func TooManyRequestsResponse(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(&ErrorMessage{err.Error()})
+}
+
 func NoContentResponse(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNoContent)
 }
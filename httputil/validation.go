@@ -0,0 +1,26 @@
+package httputil
+
+import (
+	"github.com/Oudwins/zog"
+	"time"
+)
+
+// IsTimezone is a zog test reporting whether the value is an IANA time zone.
+func IsTimezone(val any, ctx zog.Ctx) bool {
+	timezone, ok := val.(string)
+
+	if !ok {
+		return false
+	}
+
+	_, err := time.LoadLocation(timezone)
+
+	return err == nil
+}
+
+// IsInTheFuture is a zog test reporting whether the value is a time after now.
+func IsInTheFuture(val any, ctx zog.Ctx) bool {
+	t, ok := val.(time.Time)
+
+	return ok && t.After(time.Now())
+}
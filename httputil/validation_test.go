@@ -0,0 +1,43 @@
+package httputil
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestIsTimezone(t *testing.T) {
+	testCases := []struct {
+		name     string
+		val      any
+		expected bool
+	}{
+		{"Should accept an IANA time zone", "America/Sao_Paulo", true},
+		{"Should reject an unknown time zone", "America/Atlantis", false},
+		{"Should reject a value that is not a string", 3, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsTimezone(tc.val, nil))
+		})
+	}
+}
+
+func TestIsInTheFuture(t *testing.T) {
+	testCases := []struct {
+		name     string
+		val      any
+		expected bool
+	}{
+		{"Should accept a time after now", time.Now().Add(time.Minute), true},
+		{"Should reject a time before now", time.Now().Add(-time.Minute), false},
+		{"Should reject a value that is not a time", "2030-01-01T00:00:00Z", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsInTheFuture(tc.val, nil))
+		})
+	}
+}
@@ -1,15 +1,38 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"github.com/Tagliatti/magalu-challenge/channels"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	contactHandler "github.com/Tagliatti/magalu-challenge/contacts/handler"
 	"github.com/Tagliatti/magalu-challenge/database"
+	"github.com/Tagliatti/magalu-challenge/dispatcher"
 	"github.com/Tagliatti/magalu-challenge/health"
+	"github.com/Tagliatti/magalu-challenge/httputil"
 	"github.com/Tagliatti/magalu-challenge/notifications"
 	"github.com/Tagliatti/magalu-challenge/notifications/handler"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	recurringHandler "github.com/Tagliatti/magalu-challenge/recurring/handler"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	suppressionHandler "github.com/Tagliatti/magalu-challenge/suppressions/handler"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	templateHandler "github.com/Tagliatti/magalu-challenge/templates/handler"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 )
 
 func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
 	db, err := database.Connect()
 
 	if err != nil {
@@ -17,19 +40,153 @@ func main() {
 	}
 	defer db.Close()
 
+	dispatcherConfig, err := dispatcher.ConfigFromEnv()
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	recurringConfig, err := recurring.ConfigFromEnv()
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	frequencyCaps, err := notifications.FrequencyCapsFromEnv()
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	senders := channels.NewRegistry()
+	senders.Register("email", channels.NewLogSender())
+
+	if os.Getenv("SMTP_HOST") != "" {
+		smtpConfig, err := channels.SMTPConfigFromEnv()
+
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		senders.Register("email", channels.NewSMTPSender(smtpConfig))
+	}
+	senders.Register("sms", channels.NewLogSender())
+	senders.Register("push", channels.NewLogSender())
+	senders.Register("whatsapp", channels.NewLogSender())
+
+	if err := senders.Validate(notifications.Types); err != nil {
+		log.Fatal(err)
+	}
+
 	notificationStorage := notifications.NewPostgresRepository(db)
+	templateStorage := templates.NewPostgresRepository(db)
+	suppressionStorage := suppressions.NewPostgresRepository(db)
+	contactStorage := contacts.NewPostgresRepository(db)
+	recurringStorage := recurring.NewPostgresRepository(db)
+	preparer := prepare.NewPreparer(notificationStorage, templateStorage, suppressionStorage, contactStorage, frequencyCaps)
 
 	healthy := health.NewHealthyHandler()
-	createNotification := handler.NewCreateHandler(notificationStorage)
-	statusNotification := handler.NewStatusHandler(notificationStorage)
-	deleteNotification := handler.NewDeleteHandler(notificationStorage)
+	createNotification := handler.NewCreateHandler(notificationStorage, preparer)
+	batchNotification := handler.NewBatchHandler(notificationStorage, preparer)
+	previewNotification := handler.NewPreviewHandler(templateStorage, preparer)
+	listNotifications := handler.NewListHandler(notificationStorage)
+	findNotification := handler.NewFindHandler(notificationStorage)
+	statusNotification := handler.NewStatusHandler(notificationStorage, frequencyCaps)
+	eventsNotification := handler.NewEventsHandler(notificationStorage)
+	retryNotification := handler.NewRetryHandler(notificationStorage)
+	updateNotification := handler.NewUpdateHandler(notificationStorage, preparer)
+	cancelNotification := handler.NewCancelHandler(notificationStorage)
+	purgeNotification := handler.NewPurgeHandler(notificationStorage)
+	createTemplate := templateHandler.NewCreateHandler(templateStorage)
+	listTemplates := templateHandler.NewListHandler(templateStorage)
+	findTemplate := templateHandler.NewFindHandler(templateStorage)
+	versionTemplate := templateHandler.NewVersionHandler(templateStorage)
+	updateTemplate := templateHandler.NewUpdateHandler(templateStorage)
+	deleteTemplate := templateHandler.NewDeleteHandler(templateStorage)
+	createSuppression := suppressionHandler.NewCreateHandler(suppressionStorage)
+	listSuppressions := suppressionHandler.NewListHandler(suppressionStorage)
+	findSuppression := suppressionHandler.NewFindHandler(suppressionStorage)
+	deleteSuppression := suppressionHandler.NewDeleteHandler(suppressionStorage)
+	createContact := contactHandler.NewCreateHandler(contactStorage)
+	listContacts := contactHandler.NewListHandler(contactStorage)
+	findContact := contactHandler.NewFindHandler(contactStorage)
+	updateContact := contactHandler.NewUpdateHandler(contactStorage)
+	deleteContact := contactHandler.NewDeleteHandler(contactStorage)
+	createRecurringNotification := recurringHandler.NewCreateHandler(recurringStorage, preparer)
+	listRecurringNotifications := recurringHandler.NewListHandler(recurringStorage)
+	findRecurringNotification := recurringHandler.NewFindHandler(recurringStorage)
+	pauseRecurringNotification := recurringHandler.NewPauseHandler(recurringStorage)
+	resumeRecurringNotification := recurringHandler.NewResumeHandler(recurringStorage)
+	endRecurringNotification := recurringHandler.NewEndHandler(recurringStorage)
 
 	server := http.NewServeMux()
 	server.HandleFunc("POST /notifications", createNotification.Handler)
+	server.HandleFunc("POST /notifications/batch", batchNotification.Handler)
+	server.HandleFunc("POST /notifications/preview", previewNotification.Handler)
+	server.HandleFunc("GET /notifications", listNotifications.Handler)
+	server.HandleFunc("GET /notifications/{id}", findNotification.Handler)
 	server.HandleFunc("GET /notifications/{id}/status", statusNotification.Handler)
-	server.HandleFunc("DELETE /notifications/{id}", deleteNotification.Handler)
+	server.HandleFunc("GET /notifications/{id}/events", eventsNotification.Handler)
+	server.HandleFunc("POST /notifications/{id}/retry", retryNotification.Handler)
+	server.HandleFunc("PATCH /notifications/{id}", updateNotification.Handler)
+	server.HandleFunc("DELETE /notifications/{id}", cancelNotification.Handler)
+	server.HandleFunc("DELETE /admin/notifications/{id}", httputil.RequireToken(os.Getenv("ADMIN_TOKEN"), purgeNotification.Handler))
+	server.HandleFunc("POST /templates", createTemplate.Handler)
+	server.HandleFunc("GET /templates", listTemplates.Handler)
+	server.HandleFunc("GET /templates/{id}", findTemplate.Handler)
+	server.HandleFunc("GET /templates/{id}/versions/{version}", versionTemplate.Handler)
+	server.HandleFunc("PUT /templates/{id}", updateTemplate.Handler)
+	server.HandleFunc("DELETE /templates/{id}", deleteTemplate.Handler)
+	server.HandleFunc("POST /suppressions", createSuppression.Handler)
+	server.HandleFunc("GET /suppressions", listSuppressions.Handler)
+	server.HandleFunc("GET /suppressions/{id}", findSuppression.Handler)
+	server.HandleFunc("DELETE /suppressions/{id}", deleteSuppression.Handler)
+	server.HandleFunc("POST /contacts", createContact.Handler)
+	server.HandleFunc("GET /contacts", listContacts.Handler)
+	server.HandleFunc("GET /contacts/{id}", findContact.Handler)
+	server.HandleFunc("PUT /contacts/{id}", updateContact.Handler)
+	server.HandleFunc("DELETE /contacts/{id}", deleteContact.Handler)
+	server.HandleFunc("POST /recurring-notifications", createRecurringNotification.Handler)
+	server.HandleFunc("GET /recurring-notifications", listRecurringNotifications.Handler)
+	server.HandleFunc("GET /recurring-notifications/{id}", findRecurringNotification.Handler)
+	server.HandleFunc("POST /recurring-notifications/{id}/pause", pauseRecurringNotification.Handler)
+	server.HandleFunc("POST /recurring-notifications/{id}/resume", resumeRecurringNotification.Handler)
+	server.HandleFunc("POST /recurring-notifications/{id}/end", endRecurringNotification.Handler)
 	server.HandleFunc("/", healthy.Handler)
 
-	log.Println("Servidor iniciado na porta 8080...")
-	log.Fatal(http.ListenAndServe(":8080", server))
+	notificationDispatcher := dispatcher.NewDispatcher(notificationStorage, templateStorage, senders, dispatcherConfig)
+	recurringMaterializer := recurring.NewMaterializer(recurringStorage, preparer, recurringConfig)
+
+	var workers sync.WaitGroup
+	workers.Add(2)
+	go func() {
+		defer workers.Done()
+		notificationDispatcher.Run(ctx)
+	}()
+	go func() {
+		defer workers.Done()
+		recurringMaterializer.Run(ctx)
+	}()
+
+	httpServer := &http.Server{Addr: ":8080", Handler: server}
+
+	go func() {
+		log.Println("Servidor iniciado na porta 8080...")
+
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Encerrando servidor...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Println(err)
+	}
+
+	workers.Wait()
 }
@@ -0,0 +1,7 @@
+ALTER TABLE notifications
+    ADD COLUMN scheduled_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN message      TEXT                     NOT NULL DEFAULT '';
+
+ALTER TABLE notifications
+    ALTER COLUMN scheduled_at DROP DEFAULT,
+    ALTER COLUMN message DROP DEFAULT;
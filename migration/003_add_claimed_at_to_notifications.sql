@@ -0,0 +1,4 @@
+ALTER TABLE notifications
+    ADD COLUMN claimed_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+CREATE INDEX notifications_due_idx ON notifications (scheduled_at) WHERE sent_at IS NULL;
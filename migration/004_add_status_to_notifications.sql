@@ -0,0 +1,17 @@
+CREATE TYPE notification_status AS ENUM ('scheduled', 'queued', 'sending', 'sent', 'failed', 'canceled', 'expired');
+
+ALTER TABLE notifications
+    ADD COLUMN status      notification_status      NOT NULL DEFAULT 'scheduled',
+    ADD COLUMN queued_at   TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    ADD COLUMN sending_at  TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    ADD COLUMN failed_at   TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    ADD COLUMN canceled_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    ADD COLUMN expired_at  TIMESTAMP WITH TIME ZONE DEFAULT NULL;
+
+UPDATE notifications SET status = 'sent' WHERE sent_at IS NOT NULL;
+UPDATE notifications SET status = 'sending', sending_at = claimed_at WHERE sent_at IS NULL AND claimed_at IS NOT NULL;
+
+DROP INDEX notifications_due_idx;
+ALTER TABLE notifications DROP COLUMN claimed_at;
+
+CREATE INDEX notifications_due_idx ON notifications (scheduled_at) WHERE status IN ('scheduled', 'queued');
@@ -0,0 +1,2 @@
+ALTER TABLE notifications
+    ADD COLUMN cancel_reason VARCHAR(255) DEFAULT NULL;
@@ -0,0 +1,15 @@
+CREATE TABLE notification_events
+(
+    id              BIGSERIAL PRIMARY KEY,
+    notification_id BIGINT                   NOT NULL REFERENCES notifications (id) ON DELETE CASCADE,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    event           VARCHAR(32)              NOT NULL,
+    status          notification_status      NOT NULL,
+    error           TEXT                     DEFAULT NULL,
+    actor           VARCHAR(64)              NOT NULL
+);
+
+CREATE INDEX notification_events_notification_id_idx ON notification_events (notification_id, id);
+
+INSERT INTO notification_events (notification_id, created_at, event, status, actor)
+SELECT id, created_at, 'created', 'scheduled', 'api' FROM notifications ORDER BY id;
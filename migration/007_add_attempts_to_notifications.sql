@@ -0,0 +1,11 @@
+ALTER TABLE notifications
+    ADD COLUMN attempts        INTEGER                  NOT NULL DEFAULT 0,
+    ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    ADD COLUMN last_error      TEXT                     DEFAULT NULL;
+
+ALTER TABLE notification_events
+    ADD COLUMN attempt INTEGER DEFAULT NULL;
+
+DROP INDEX notifications_due_idx;
+
+CREATE INDEX notifications_due_idx ON notifications (COALESCE(next_attempt_at, scheduled_at)) WHERE status IN ('scheduled', 'queued');
@@ -0,0 +1,4 @@
+CREATE INDEX notifications_recipient_idx ON notifications (recipient, id);
+CREATE INDEX notifications_status_idx ON notifications (status, id);
+CREATE INDEX notifications_created_at_idx ON notifications (created_at);
+CREATE INDEX notifications_scheduled_at_idx ON notifications (scheduled_at);
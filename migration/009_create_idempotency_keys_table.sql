@@ -0,0 +1,8 @@
+CREATE TABLE idempotency_keys (
+    client_id VARCHAR(255) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    notification_id BIGINT REFERENCES notifications (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (client_id, key)
+);
@@ -0,0 +1,30 @@
+CREATE TABLE templates
+(
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(255)             NOT NULL UNIQUE,
+    version    INTEGER                  NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE template_versions
+(
+    template_id BIGINT                   NOT NULL REFERENCES templates (id) ON DELETE CASCADE,
+    version     INTEGER                  NOT NULL,
+    subject     TEXT                     DEFAULT NULL,
+    body        TEXT                     NOT NULL,
+    html_body   TEXT                     DEFAULT NULL,
+    variables   TEXT[]                   NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (template_id, version)
+);
+
+ALTER TABLE notifications
+    ALTER COLUMN message DROP NOT NULL,
+    ADD COLUMN template_id      BIGINT  DEFAULT NULL,
+    ADD COLUMN template_version INTEGER DEFAULT NULL,
+    ADD COLUMN variables        JSONB   DEFAULT NULL,
+    ADD CONSTRAINT notifications_template_fk FOREIGN KEY (template_id, template_version) REFERENCES template_versions (template_id, version),
+    ADD CONSTRAINT notifications_message_or_template_check CHECK (message IS NOT NULL OR template_id IS NOT NULL);
+
+CREATE INDEX notifications_template_idx ON notifications (template_id, template_version);
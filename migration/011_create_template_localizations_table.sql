@@ -0,0 +1,14 @@
+CREATE TABLE template_localizations
+(
+    template_id BIGINT      NOT NULL,
+    version     INTEGER     NOT NULL,
+    locale      VARCHAR(16) NOT NULL,
+    subject     TEXT        DEFAULT NULL,
+    body        TEXT        NOT NULL,
+    html_body   TEXT        DEFAULT NULL,
+    PRIMARY KEY (template_id, version, locale),
+    FOREIGN KEY (template_id, version) REFERENCES template_versions (template_id, version) ON DELETE CASCADE
+);
+
+ALTER TABLE notifications
+    ADD COLUMN locale VARCHAR(16) DEFAULT NULL;
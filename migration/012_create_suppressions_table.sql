@@ -0,0 +1,10 @@
+CREATE TABLE suppressions
+(
+    id         BIGSERIAL PRIMARY KEY,
+    type       notification_type        NOT NULL,
+    recipient  VARCHAR(255)             NOT NULL,
+    reason     VARCHAR(32)              NOT NULL,
+    note       TEXT                     DEFAULT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (type, recipient)
+);
@@ -0,0 +1,18 @@
+CREATE TABLE contacts
+(
+    id                 BIGSERIAL PRIMARY KEY,
+    customer_id        VARCHAR(255)             NOT NULL UNIQUE,
+    email              VARCHAR(255)             DEFAULT NULL,
+    phone              VARCHAR(16)              DEFAULT NULL,
+    whatsapp           VARCHAR(16)              DEFAULT NULL,
+    push_tokens        TEXT[]                   NOT NULL DEFAULT '{}',
+    preferred_channels notification_type[]      NOT NULL DEFAULT '{}',
+    quiet_hours        JSONB                    DEFAULT NULL,
+    created_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE notifications
+    ADD COLUMN contact_id BIGINT DEFAULT NULL REFERENCES contacts (id) ON DELETE SET NULL;
+
+CREATE INDEX notifications_contact_idx ON notifications (contact_id) WHERE contact_id IS NOT NULL;
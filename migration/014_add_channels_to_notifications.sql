@@ -0,0 +1,10 @@
+ALTER TABLE notifications
+    ADD COLUMN channels         JSONB             DEFAULT NULL,
+    ADD COLUMN channel_position INTEGER           NOT NULL DEFAULT 0,
+    ADD COLUMN sent_channel     notification_type DEFAULT NULL;
+
+UPDATE notifications SET channels = jsonb_build_array(jsonb_build_object('type', type, 'recipient', recipient));
+UPDATE notifications SET sent_channel = type WHERE status = 'sent';
+
+ALTER TABLE notifications
+    ALTER COLUMN channels SET NOT NULL;
@@ -0,0 +1,13 @@
+ALTER TABLE notifications
+    ADD COLUMN send_window        JSONB       DEFAULT NULL,
+    ADD COLUMN timezone           VARCHAR(64) DEFAULT NULL,
+    ADD COLUMN deliver_not_before TIMESTAMPTZ DEFAULT NULL;
+
+UPDATE notifications SET deliver_not_before = scheduled_at;
+
+ALTER TABLE notifications
+    ALTER COLUMN deliver_not_before SET NOT NULL;
+
+DROP INDEX notifications_due_idx;
+
+CREATE INDEX notifications_due_idx ON notifications (COALESCE(next_attempt_at, deliver_not_before)) WHERE status IN ('scheduled', 'queued');
@@ -0,0 +1,25 @@
+CREATE TYPE recurring_notification_status AS ENUM ('active', 'paused', 'ended');
+
+CREATE TABLE recurring_notifications
+(
+    id                 BIGSERIAL PRIMARY KEY,
+    notification       JSONB                         NOT NULL,
+    rrule              TEXT                          DEFAULT NULL,
+    cron               VARCHAR(255)                  DEFAULT NULL,
+    timezone           VARCHAR(64)                   NOT NULL DEFAULT 'UTC',
+    starts_at          TIMESTAMP WITH TIME ZONE      NOT NULL,
+    ends_at            TIMESTAMP WITH TIME ZONE      DEFAULT NULL,
+    status             recurring_notification_status NOT NULL DEFAULT 'active',
+    materialized_until TIMESTAMP WITH TIME ZONE      NOT NULL,
+    created_at         TIMESTAMP WITH TIME ZONE      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    paused_at          TIMESTAMP WITH TIME ZONE      DEFAULT NULL,
+    ended_at           TIMESTAMP WITH TIME ZONE      DEFAULT NULL,
+    CHECK ((rrule IS NULL) <> (cron IS NULL))
+);
+
+CREATE INDEX recurring_notifications_due_idx ON recurring_notifications (materialized_until) WHERE status = 'active';
+
+ALTER TABLE notifications
+    ADD COLUMN recurring_notification_id BIGINT DEFAULT NULL REFERENCES recurring_notifications (id) ON DELETE SET NULL;
+
+CREATE INDEX notifications_recurring_notification_idx ON notifications (recurring_notification_id) WHERE recurring_notification_id IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE notifications
+    ADD COLUMN expires_at TIMESTAMPTZ DEFAULT NULL;
+
+CREATE INDEX notifications_expiring_idx ON notifications (expires_at) WHERE expires_at IS NOT NULL AND status IN ('scheduled', 'queued', 'sending');
@@ -0,0 +1,8 @@
+CREATE TYPE notification_priority AS ENUM ('critical', 'high', 'normal', 'low');
+
+ALTER TABLE notifications
+    ADD COLUMN priority notification_priority NOT NULL DEFAULT 'normal';
+
+DROP INDEX notifications_due_idx;
+
+CREATE INDEX notifications_due_idx ON notifications (priority, COALESCE(next_attempt_at, deliver_not_before)) WHERE status IN ('scheduled', 'queued');
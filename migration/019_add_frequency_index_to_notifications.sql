@@ -0,0 +1 @@
+CREATE INDEX notifications_frequency_idx ON notifications (recipient, type, COALESCE(sent_at, deliver_not_before)) WHERE status IN ('scheduled', 'queued', 'sending', 'sent');
@@ -0,0 +1,8 @@
+ALTER TABLE notifications
+    ADD COLUMN quiet_hours JSONB DEFAULT NULL;
+
+UPDATE notifications
+SET quiet_hours = contacts.quiet_hours
+FROM contacts
+WHERE notifications.contact_id = contacts.id
+  AND notifications.status IN ('scheduled', 'queued', 'sending');
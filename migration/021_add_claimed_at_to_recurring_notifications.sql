@@ -0,0 +1,2 @@
+ALTER TABLE recurring_notifications
+    ADD COLUMN claimed_at TIMESTAMP WITH TIME ZONE DEFAULT NULL;
@@ -0,0 +1,52 @@
+package notifications
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Channel is one step of the fallback chain of a notification. An empty
+// recipient marks a channel the notification can not be delivered on, which is
+// skipped.
+type Channel struct {
+	Type      string `json:"type"`
+	Recipient string `json:"recipient"`
+}
+
+// Channels is the ordered fallback chain of a notification, stored as JSONB.
+type Channels []Channel
+
+func (c Channels) Value() (driver.Value, error) {
+	if c == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(c)
+}
+
+func (c *Channels) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(src, c)
+	case string:
+		return json.Unmarshal([]byte(src), c)
+	}
+
+	return fmt.Errorf("cannot scan %T into Channels", src)
+}
+
+// Next returns the position of the first channel after position that has a
+// recipient, or false when the chain is exhausted.
+func (c Channels) Next(position int) (int, bool) {
+	for next := position + 1; next < len(c); next++ {
+		if c[next].Recipient != "" {
+			return next, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,26 @@
+package notifications
+
+import "time"
+
+const (
+	ActorAPI        = "api"
+	ActorDispatcher = "dispatcher"
+)
+
+const (
+	EventCreated       = "created"
+	EventAttemptFailed = "attempt_failed"
+	EventRetried       = "retried"
+	EventUpdated       = "updated"
+	EventFallback      = "fallback"
+)
+
+type Event struct {
+	Id        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Event     string    `json:"event"`
+	Status    Status    `json:"status"`
+	Attempt   *int      `json:"attempt"`
+	Error     *string   `json:"error"`
+	Actor     string    `json:"actor"`
+}
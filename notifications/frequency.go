@@ -0,0 +1,148 @@
+package notifications
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FrequencyCapReject = "reject"
+	FrequencyCapDefer  = "defer"
+)
+
+var defaultFrequencyCap = FrequencyCap{
+	Window: 24 * time.Hour,
+	Mode:   FrequencyCapReject,
+}
+
+// FrequencyCap limits a recipient to fewer than Max other notifications of a type
+// delivered within Window before or after each one. Over the cap, a new
+// notification is rejected or, with the defer mode, delayed to the next allowed
+// time. A zero Max disables the cap.
+type FrequencyCap struct {
+	Max    int
+	Window time.Duration
+	Mode   string
+}
+
+type FrequencyCaps map[string]FrequencyCap
+
+// RecipientKey identifies the notifications of a type to a normalized recipient,
+// which a frequency cap counts together.
+type RecipientKey struct {
+	Type      string
+	Recipient string
+}
+
+// FrequencyCapUsage reports how close the recipient of a notification is to the
+// cap of its type.
+type FrequencyCapUsage struct {
+	Max    int    `json:"max"`
+	Window string `json:"window"`
+	Count  int    `json:"count"`
+}
+
+// For returns the cap of the notification type, when it is capped.
+func (c FrequencyCaps) For(notificationType string) (FrequencyCap, bool) {
+	frequencyCap, ok := c[notificationType]
+
+	return frequencyCap, ok && frequencyCap.Max > 0
+}
+
+// Count returns how many of the sorted deliveries are within the window of t.
+func (c FrequencyCap) Count(deliveries []time.Time, t time.Time) int {
+	count := 0
+
+	for _, delivery := range deliveries {
+		if delivery.After(t.Add(-c.Window)) && delivery.Before(t.Add(c.Window)) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Next returns the first time from t on that is not over the cap, given the
+// sorted deliveries of the other notifications of the recipient.
+func (c FrequencyCap) Next(deliveries []time.Time, t time.Time) time.Time {
+	if c.Count(deliveries, t) < c.Max {
+		return t
+	}
+
+	// The count only drops when a delivery leaves the window.
+	for _, delivery := range deliveries {
+		next := delivery.Add(c.Window)
+
+		if next.After(t) && c.Count(deliveries, next) < c.Max {
+			return next
+		}
+	}
+
+	return deliveries[len(deliveries)-1].Add(c.Window)
+}
+
+// Usage returns the usage of the cap by the deliveries around t.
+func (c FrequencyCap) Usage(deliveries []time.Time, t time.Time) *FrequencyCapUsage {
+	return &FrequencyCapUsage{Max: c.Max, Window: c.Window.String(), Count: c.Count(deliveries, t)}
+}
+
+func FrequencyCapsFromEnv() (FrequencyCaps, error) {
+	baseCap, err := frequencyCapFromEnv("FREQUENCY_CAP_", defaultFrequencyCap)
+
+	if err != nil {
+		return nil, err
+	}
+
+	frequencyCaps := make(FrequencyCaps)
+
+	for _, notificationType := range Types {
+		frequencyCap, err := frequencyCapFromEnv("FREQUENCY_CAP_"+strings.ToUpper(notificationType)+"_", baseCap)
+
+		if err != nil {
+			return nil, err
+		}
+
+		frequencyCaps[notificationType] = frequencyCap
+	}
+
+	return frequencyCaps, nil
+}
+
+// frequencyCapFromEnv reads <prefix>MAX, <prefix>WINDOW and <prefix>MODE, keeping
+// the fallback value for any variable that is not set.
+func frequencyCapFromEnv(prefix string, fallback FrequencyCap) (FrequencyCap, error) {
+	frequencyCap := fallback
+
+	if value := os.Getenv(prefix + "MAX"); value != "" {
+		maxCount, err := strconv.Atoi(value)
+
+		if err != nil || maxCount < 0 {
+			return frequencyCap, fmt.Errorf("invalid %sMAX %q", prefix, value)
+		}
+
+		frequencyCap.Max = maxCount
+	}
+
+	if value := os.Getenv(prefix + "WINDOW"); value != "" {
+		window, err := time.ParseDuration(value)
+
+		if err != nil || window <= 0 {
+			return frequencyCap, fmt.Errorf("invalid %sWINDOW %q", prefix, value)
+		}
+
+		frequencyCap.Window = window
+	}
+
+	if value := os.Getenv(prefix + "MODE"); value != "" {
+		if value != FrequencyCapReject && value != FrequencyCapDefer {
+			return frequencyCap, fmt.Errorf("invalid %sMODE %q", prefix, value)
+		}
+
+		frequencyCap.Mode = value
+	}
+
+	return frequencyCap, nil
+}
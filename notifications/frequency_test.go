@@ -0,0 +1,75 @@
+package notifications
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFrequencyCapNext(t *testing.T) {
+	at := func(day, hour int) time.Time {
+		return time.Date(2030, time.January, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	frequencyCap := FrequencyCap{Max: 2, Window: 24 * time.Hour, Mode: FrequencyCapReject}
+
+	testCases := []struct {
+		name       string
+		deliveries []time.Time
+		t          time.Time
+		expected   time.Time
+	}{
+		{"Should keep a time under the cap", []time.Time{at(7, 8)}, at(7, 12), at(7, 12)},
+		{"Should keep a time whose window has left the deliveries", []time.Time{at(6, 8), at(6, 10)}, at(7, 10), at(7, 10)},
+		{"Should move a time over the cap to when the first delivery leaves the window", []time.Time{at(7, 8), at(7, 10)}, at(7, 12), at(8, 8)},
+		{"Should count the deliveries scheduled after the time", []time.Time{at(7, 14), at(7, 16)}, at(7, 12), at(8, 14)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next := frequencyCap.Next(tc.deliveries, tc.t)
+
+			assert.True(t, tc.expected.Equal(next), "expected %v, got %v", tc.expected, next)
+		})
+	}
+}
+
+func TestFrequencyCapsFromEnv(t *testing.T) {
+	t.Run("Should override the default cap per notification type", func(t *testing.T) {
+		t.Setenv("FREQUENCY_CAP_MAX", "5")
+		t.Setenv("FREQUENCY_CAP_PUSH_MAX", "3")
+		t.Setenv("FREQUENCY_CAP_PUSH_MODE", "defer")
+
+		frequencyCaps, err := FrequencyCapsFromEnv()
+
+		assert.Nil(t, err)
+
+		pushCap, ok := frequencyCaps.For("push")
+
+		assert.True(t, ok)
+		assert.Equal(t, FrequencyCap{Max: 3, Window: 24 * time.Hour, Mode: FrequencyCapDefer}, pushCap)
+
+		emailCap, ok := frequencyCaps.For("email")
+
+		assert.True(t, ok)
+		assert.Equal(t, FrequencyCap{Max: 5, Window: 24 * time.Hour, Mode: FrequencyCapReject}, emailCap)
+	})
+
+	t.Run("Should not cap types without a max", func(t *testing.T) {
+		frequencyCaps, err := FrequencyCapsFromEnv()
+
+		assert.Nil(t, err)
+
+		_, ok := frequencyCaps.For("sms")
+
+		assert.False(t, ok)
+	})
+
+	t.Run("Should return error when the mode is invalid", func(t *testing.T) {
+		t.Setenv("FREQUENCY_CAP_SMS_MODE", "drop")
+
+		_, err := FrequencyCapsFromEnv()
+
+		assert.NotNil(t, err)
+	})
+}
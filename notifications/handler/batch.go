@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+const maxBatchSize = 50000
+
+var errEmptyBatch = errors.New("The batch must have at least one notification")
+var errBatchTooLarge = errors.New("The batch must have at most " + strconv.Itoa(maxBatchSize) + " notifications")
+var errInvalidBatchItem = errors.New("The item must be a notification object")
+
+type BatchHandler struct {
+	notificationRepository notifications.Repository
+	preparer               *prepare.Preparer
+}
+
+func NewBatchHandler(notificationRepository notifications.Repository, preparer *prepare.Preparer) *BatchHandler {
+	return &BatchHandler{
+		notificationRepository: notificationRepository,
+		preparer:               preparer,
+	}
+}
+
+func (h *BatchHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	createNotifications, err := decodeBatch(r)
+
+	if err != nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
+	if len(createNotifications) == 0 {
+		httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{Errors: []string{errEmptyBatch.Error()}})
+		return
+	}
+
+	if len(createNotifications) > maxBatchSize {
+		httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{Errors: []string{errBatchTooLarge.Error()}})
+		return
+	}
+
+	result := &notifications.BatchResult{Results: make([]*notifications.BatchItemResult, len(createNotifications))}
+	items := make([]*notifications.CreateNotification, 0, len(createNotifications))
+	itemResults := make([]*notifications.BatchItemResult, 0, len(createNotifications))
+
+	for i, createNotification := range createNotifications {
+		itemResult := &notifications.BatchItemResult{Index: i}
+		result.Results[i] = itemResult
+
+		if createNotification == nil {
+			itemResult.Errors = []string{errInvalidBatchItem.Error()}
+			result.Failed++
+			continue
+		}
+
+		items = append(items, createNotification)
+		itemResults = append(itemResults, itemResult)
+	}
+
+	errs, err := h.preparer.Prepare(items)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	valid := make([]*notifications.CreateNotification, 0, len(items))
+	validResults := make([]*notifications.BatchItemResult, 0, len(items))
+
+	for i, createNotification := range items {
+		if validationError, ok := errs[i]; ok {
+			itemResults[i].Errors = validationError.Errors
+			result.Failed++
+			continue
+		}
+
+		valid = append(valid, createNotification)
+		validResults = append(validResults, itemResults[i])
+	}
+
+	if len(valid) > 0 {
+		ids, err := h.notificationRepository.CreateNotifications(valid)
+
+		if err != nil {
+			httputil.InternalServerErrorResponse(w, err)
+			return
+		}
+
+		for i, id := range ids {
+			validResults[i].Id = &id
+		}
+
+		result.Created = len(ids)
+	}
+
+	httputil.OkResponse(w, result)
+}
+
+// decodeBatch accepts either a JSON array or, when the request is sent as
+// application/x-ndjson, one notification object per line.
+func decodeBatch(r *http.Request) ([]*notifications.CreateNotification, error) {
+	var createNotifications []*notifications.CreateNotification
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	if mediaType != "application/x-ndjson" {
+		err := json.NewDecoder(r.Body).Decode(&createNotifications)
+
+		return createNotifications, err
+	}
+
+	decoder := json.NewDecoder(r.Body)
+
+	for {
+		var createNotification *notifications.CreateNotification
+		err := decoder.Decode(&createNotification)
+
+		if errors.Is(err, io.EOF) {
+			return createNotifications, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		createNotifications = append(createNotifications, createNotification)
+	}
+}
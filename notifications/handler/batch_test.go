@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"encoding/json"
+	contactMocks "github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	suppressionMocks "github.com/Tagliatti/magalu-challenge/suppressions/mocks"
+	templateMocks "github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuccessBatch(t *testing.T) {
+	scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	first := `{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"` + scheduledAt + `"}`
+	invalid := `{"type":"invalid","recipient":"+5511999999999","message":"Hello","scheduled_at":"` + scheduledAt + `"}`
+	second := `{"type":"email","recipient":"test@example.com","message":"Hello","scheduled_at":"` + scheduledAt + `"}`
+
+	testCases := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"Should create valid notifications of a JSON array", "application/json", "[" + first + "," + invalid + "," + second + "]"},
+		{"Should create valid notifications of a NDJSON stream", "application/x-ndjson", first + "\n" + invalid + "\n" + second + "\n"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications/batch", strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", tc.contentType)
+
+			repository := mocks.NewRepository(t)
+			repository.On("CreateNotifications", mock.MatchedBy(func(createNotifications []*notifications.CreateNotification) bool {
+				return len(createNotifications) == 2 &&
+					createNotifications[0].Type == "sms" &&
+					createNotifications[1].Type == "email"
+			})).Return([]int64{10, 11}, nil)
+
+			NewBatchHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+				Handler(response, request)
+
+			var result notifications.BatchResult
+			err := json.Unmarshal(response.Body.Bytes(), &result)
+
+			require.Nilf(t, err, "Failed to unmarshal JSON: %v", err)
+			require.Len(t, result.Results, 3)
+
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.Equal(t, 2, result.Created)
+			assert.Equal(t, 1, result.Failed)
+			require.NotNil(t, result.Results[0].Id)
+			assert.Equal(t, int64(10), *result.Results[0].Id)
+			assert.Nil(t, result.Results[1].Id)
+			assert.Contains(t, result.Results[1].Errors[0], `"type"`)
+			require.NotNil(t, result.Results[2].Id)
+			assert.Equal(t, int64(11), *result.Results[2].Id)
+		})
+	}
+
+	t.Run("Should not call the repository when every item is invalid", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications/batch", strings.NewReader("["+invalid+",null]"))
+
+		repository := mocks.NewRepository(t)
+
+		NewBatchHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		var result notifications.BatchResult
+		err := json.Unmarshal(response.Body.Bytes(), &result)
+
+		require.Nilf(t, err, "Failed to unmarshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, 0, result.Created)
+		assert.Equal(t, 2, result.Failed)
+		assert.Equal(t, []string{errInvalidBatchItem.Error()}, result.Results[1].Errors)
+	})
+}
+
+func TestSuppressionOnBatch(t *testing.T) {
+	t.Run("Should reject only the items whose recipient is suppressed", func(t *testing.T) {
+		scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+		body := `[{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"` + scheduledAt + `"},` +
+			`{"type":"email","recipient":"test@example.com","message":"Hello","scheduled_at":"` + scheduledAt + `"}]`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications/batch", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateNotifications", mock.MatchedBy(func(createNotifications []*notifications.CreateNotification) bool {
+			return len(createNotifications) == 1 && createNotifications[0].Type == "email"
+		})).Return([]int64{10}, nil)
+
+		suppressed := suppressions.Key{Type: "sms", Recipient: "+5511999999999"}
+
+		suppressionRepository := suppressionMocks.NewRepository(t)
+		suppressionRepository.On("FindSuppressed", mock.Anything).Return(map[suppressions.Key]string{suppressed: "complaint"}, nil)
+
+		NewBatchHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), suppressionRepository, contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		var result notifications.BatchResult
+		err := json.Unmarshal(response.Body.Bytes(), &result)
+
+		require.Nilf(t, err, "Failed to unmarshal JSON: %v", err)
+		require.Len(t, result.Results, 2)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.Failed)
+		assert.Nil(t, result.Results[0].Id)
+		assert.Equal(t, []string{`The field "recipient" is suppressed for "sms" notifications (complaint)`}, result.Results[0].Errors)
+		require.NotNil(t, result.Results[1].Id)
+		assert.Equal(t, int64(10), *result.Results[1].Id)
+	})
+}
+
+func TestInvalidRequestOnBatch(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		contentType          string
+		body                 string
+		expectedStatusCode   int
+		expectedBodyContains string
+	}{
+		{"Should return 400 when body is not an array", "application/json", `{}`, http.StatusBadRequest, errInvalidBody.Error()},
+		{"Should return 400 when a NDJSON line is invalid", "application/x-ndjson", "{}\ninvalid\n", http.StatusBadRequest, errInvalidBody.Error()},
+		{"Should return 422 when batch is empty", "application/json", `[]`, http.StatusUnprocessableEntity, errEmptyBatch.Error()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications/batch", strings.NewReader(tc.body))
+			request.Header.Set("Content-Type", tc.contentType)
+
+			repository := mocks.NewRepository(t)
+
+			NewBatchHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+				Handler(response, request)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
+
+func TestInternalServerErrorOnBatch(t *testing.T) {
+	t.Run("Should return 500 when the repository fails", func(t *testing.T) {
+		scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+		body := `[{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"` + scheduledAt + `"}]`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications/batch", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateNotifications", mock.Anything).Return(nil, assert.AnError)
+
+		NewBatchHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(assert.AnError))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestFrequencyCapOnBatch(t *testing.T) {
+	scheduledAt := time.Date(2030, time.January, 7, 12, 0, 0, 0, time.UTC)
+	body := `[{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"2030-01-07T12:00:00Z"},` +
+		`{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"2030-01-07T13:00:00Z"}]`
+
+	key := notifications.RecipientKey{Type: "sms", Recipient: "+5511999999999"}
+	since := map[notifications.RecipientKey]time.Time{key: scheduledAt.Add(-24 * time.Hour)}
+
+	capped := func(mode string) notifications.FrequencyCaps {
+		return notifications.FrequencyCaps{"sms": {Max: 1, Window: 24 * time.Hour, Mode: mode}}
+	}
+
+	t.Run("Should reject the items over the cap counting the earlier items of the batch", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications/batch", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindRecipientsDeliveries", since).Return(map[notifications.RecipientKey][]time.Time{key: {}}, nil).Once()
+		repository.On("CreateNotifications", mock.MatchedBy(func(createNotifications []*notifications.CreateNotification) bool {
+			return len(createNotifications) == 1 && createNotifications[0].ScheduledAt.Equal(scheduledAt)
+		})).Return([]int64{10}, nil)
+
+		NewBatchHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapReject))).
+			Handler(response, request)
+
+		var result notifications.BatchResult
+		err := json.Unmarshal(response.Body.Bytes(), &result)
+
+		require.Nilf(t, err, "Failed to unmarshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.Failed)
+		assert.Nil(t, result.Results[1].Id)
+		assert.Contains(t, result.Results[1].Errors[0], "the next allowed time is 2030-01-08T12:00:00Z")
+	})
+
+	t.Run("Should defer the items over the cap to the next allowed time", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications/batch", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindRecipientsDeliveries", since).Return(map[notifications.RecipientKey][]time.Time{key: {}}, nil).Once()
+		repository.On("CreateNotifications", mock.MatchedBy(func(createNotifications []*notifications.CreateNotification) bool {
+			return len(createNotifications) == 2 &&
+				createNotifications[0].DeliverNotBefore.IsZero() &&
+				createNotifications[1].DeliverNotBefore.Equal(scheduledAt.Add(24*time.Hour))
+		})).Return([]int64{10, 11}, nil)
+
+		NewBatchHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapDefer))).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"created":2`)
+	})
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"io"
+	"net/http"
+)
+
+var cancelNotificationSchema = zog.Struct(zog.Schema{
+	"reason": zog.String().Trim().Max(255),
+})
+
+var errNotCancelable = errors.New("notification can no longer be canceled: it was already sent, is being sent or has finished")
+
+type CancelHandler struct {
+	notificationRepository notifications.Repository
+}
+
+func NewCancelHandler(notificationRepository notifications.Repository) *CancelHandler {
+	return &CancelHandler{notificationRepository: notificationRepository}
+}
+
+func (h *CancelHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	var cancelNotification notifications.CancelNotification
+
+	if r.Body != nil {
+		defer r.Body.Close()
+
+		err := json.NewDecoder(r.Body).Decode(&cancelNotification)
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			httputil.BadRequestResponse(w, errInvalidBody)
+			return
+		}
+	}
+
+	bodyValidationErrors := cancelNotificationSchema.Validate(&cancelNotification)
+
+	if bodyValidationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(bodyValidationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	found, err := h.notificationRepository.CancelNotificationByID(id, cancelNotification.Reason)
+
+	if err != nil {
+		if errors.Is(err, notifications.ErrInvalidStatusTransition) {
+			httputil.ConflictResponse(w, errNotCancelable)
+			return
+		}
+
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !found {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.NoContentResponse(w)
+}
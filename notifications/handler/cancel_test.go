@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSuccessCancel(t *testing.T) {
+	testCases := []struct {
+		name           string
+		body           io.Reader
+		expectedReason string
+	}{
+		{"Should cancel a notification successfully (without body)", nil, ""},
+		{"Should cancel a notification successfully (with reason)", strings.NewReader(`{"reason":"customer request"}`), "customer request"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("DELETE", "/notifications/1", tc.body)
+			request.SetPathValue("id", "1")
+
+			repository := mocks.NewRepository(t)
+			repository.On("CancelNotificationByID", int64(1), tc.expectedReason).Return(true, nil)
+
+			NewCancelHandler(repository).
+				Handler(response, request)
+
+			assert.Equal(t, http.StatusNoContent, response.Code)
+			assert.Equal(t, "", response.Body.String())
+		})
+	}
+}
+
+func TestConflictOnCancel(t *testing.T) {
+	t.Run("Should return 409 when notification can no longer be canceled", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("DELETE", "/notifications/1", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("CancelNotificationByID", int64(1), "").Return(false, notifications.ErrInvalidStatusTransition)
+
+		NewCancelHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusConflict
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errNotCancelable))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestNotFoundOnCancel(t *testing.T) {
+	t.Run("Should return 404 when notification not found", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("DELETE", "/notifications/1", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("CancelNotificationByID", int64(1), "").Return(false, nil)
+
+		NewCancelHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusNotFound
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errNotFound))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestInvalidRequestOnCancel(t *testing.T) {
+	testCases := []struct {
+		name               string
+		id                 string
+		body               io.Reader
+		expectedStatusCode int
+	}{
+		{"Should return 400 when id is invalid", "invalid-id", nil, http.StatusBadRequest},
+		{"Should return 400 when body is invalid", "1", strings.NewReader(`invalid`), http.StatusBadRequest},
+		{"Should return 422 when reason is too long", "1", strings.NewReader(`{"reason":"` + strings.Repeat("a", 256) + `"}`), http.StatusUnprocessableEntity},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("DELETE", "/notifications/"+tc.id, tc.body)
+			request.SetPathValue("id", tc.id)
+
+			repository := mocks.NewRepository(t)
+
+			NewCancelHandler(repository).
+				Handler(response, request)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+		})
+	}
+}
@@ -1,60 +1,157 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"github.com/Oudwins/zog"
 	"github.com/Tagliatti/magalu-challenge/httputil"
 	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"io"
 	"net/http"
 )
 
-var createNotificationSchema = zog.Struct(zog.Schema{
-	"type":      zog.String().Trim().Required().OneOf([]string{"email", "sms", "push", "whatsapp"}),
-	"recipient": zog.String().Min(3).Max(255).Required(),
-})
-
 var errInvalidBody = errors.New("invalid request body")
+var errInvalidIdempotencyKey = errors.New("invalid Idempotency-Key or X-Client-Id header, they must have at most 255 characters")
+var errIdempotencyKeyReused = errors.New(`The header "Idempotency-Key" was already used with a different request body`)
 
 type CreateHandler struct {
 	notificationRepository notifications.Repository
+	preparer               *prepare.Preparer
 }
 
-func NewCreateHandler(notificationRepository notifications.Repository) *CreateHandler {
-	return &CreateHandler{notificationRepository: notificationRepository}
+func NewCreateHandler(notificationRepository notifications.Repository, preparer *prepare.Preparer) *CreateHandler {
+	return &CreateHandler{
+		notificationRepository: notificationRepository,
+		preparer:               preparer,
+	}
 }
 
 func (h *CreateHandler) Handler(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 
+	body, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
 	var createNotification *notifications.CreateNotification
-	err := json.NewDecoder(r.Body).Decode(&createNotification)
+	err = json.Unmarshal(body, &createNotification)
 
 	if err != nil {
 		httputil.BadRequestResponse(w, errInvalidBody)
 		return
 	}
 
-	validationErrors := createNotificationSchema.Validate(createNotification)
+	if len(r.Header.Get("Idempotency-Key")) > 255 || len(r.Header.Get("X-Client-Id")) > 255 {
+		httputil.BadRequestResponse(w, errInvalidIdempotencyKey)
+		return
+	}
 
-	if validationErrors != nil {
-		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(validationErrors)
-		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+	if createNotification == nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
 		return
 	}
 
-	id, err := h.notificationRepository.CreateNotification(createNotification)
+	idempotencyKey := newIdempotencyKey(r, body)
+
+	// A replay returns the original notification even if it would no longer be
+	// valid, e.g. when it arrives after the scheduled time.
+	if idempotencyKey != nil {
+		id, err := h.notificationRepository.FindIdempotentNotification(idempotencyKey)
+
+		if errors.Is(err, notifications.ErrIdempotencyKeyReused) {
+			httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{Errors: []string{errIdempotencyKeyReused.Error()}})
+			return
+		}
+
+		if err != nil {
+			httputil.InternalServerErrorResponse(w, err)
+			return
+		}
+
+		if id != 0 {
+			h.created(w, id, true)
+			return
+		}
+	}
+
+	errs, err := h.preparer.Prepare([]*notifications.CreateNotification{createNotification})
 
 	if err != nil {
 		httputil.InternalServerErrorResponse(w, err)
 		return
 	}
 
+	if validationError, ok := errs[0]; ok && validationError.FrequencyCapped {
+		httputil.TooManyRequestsResponse(w, validationError)
+		return
+	}
+
+	if validationError, ok := errs[0]; ok {
+		httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{Errors: validationError.Errors})
+		return
+	}
+
+	id, replayed, err := h.createNotification(idempotencyKey, createNotification)
+
+	if errors.Is(err, notifications.ErrIdempotencyKeyReused) {
+		httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{Errors: []string{errIdempotencyKeyReused.Error()}})
+		return
+	}
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	h.created(w, id, replayed)
+}
+
+func (h *CreateHandler) created(w http.ResponseWriter, id int64, replayed bool) {
 	notification, err := h.notificationRepository.FindNotificationByID(id)
 
 	if err != nil {
 		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
 	}
 
 	httputil.CreatedResponse(w, notification)
 }
+
+// createNotification honours the idempotency key, reporting whether a notification
+// created concurrently with the same key was returned instead.
+func (h *CreateHandler) createNotification(idempotencyKey *notifications.IdempotencyKey, createNotification *notifications.CreateNotification) (int64, bool, error) {
+	if idempotencyKey == nil {
+		id, err := h.notificationRepository.CreateNotification(createNotification)
+
+		return id, false, err
+	}
+
+	return h.notificationRepository.CreateNotificationWithIdempotencyKey(idempotencyKey, createNotification)
+}
+
+// newIdempotencyKey reads the Idempotency-Key header, scoped by the X-Client-Id
+// header, hashing the raw request body so replays are compared byte for byte.
+func newIdempotencyKey(r *http.Request, body []byte) *notifications.IdempotencyKey {
+	key := r.Header.Get("Idempotency-Key")
+
+	if key == "" {
+		return nil
+	}
+
+	requestHash := sha256.Sum256(body)
+
+	return &notifications.IdempotencyKey{
+		ClientId:    r.Header.Get("X-Client-Id"),
+		Key:         key,
+		RequestHash: hex.EncodeToString(requestHash[:]),
+	}
+}
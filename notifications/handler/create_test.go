@@ -2,11 +2,21 @@ package handler
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	contactMocks "github.com/Tagliatti/magalu-challenge/contacts/mocks"
 	"github.com/Tagliatti/magalu-challenge/httputil"
 	"github.com/Tagliatti/magalu-challenge/notifications"
 	"github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	suppressionMocks "github.com/Tagliatti/magalu-challenge/suppressions/mocks"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	templateMocks "github.com/Tagliatti/magalu-challenge/templates/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"io"
 	"net/http"
@@ -18,8 +28,9 @@ import (
 
 func TestSuccessCreate(t *testing.T) {
 	t.Run("Should create a notification successfully", func(t *testing.T) {
-		body := bytes.NewBufferString(`{"type":"sms","recipient":"1234567890"}`)
 		fixedTime := time.Now().UTC()
+		scheduledAt := fixedTime.Add(time.Hour).Format(time.RFC3339)
+		body := bytes.NewBufferString(`{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"` + scheduledAt + `"}`)
 
 		var createNotification notifications.CreateNotification
 
@@ -27,13 +38,17 @@ func TestSuccessCreate(t *testing.T) {
 
 		require.Nilf(t, err, "Failed to unmarshal JSON: %v", err)
 
+		createNotification.Chain = notifications.Channels{{Type: "sms", Recipient: "+5511999999999"}}
+
 		notification := notifications.Notification{
-			Id:        1,
-			Type:      createNotification.Type,
-			Recipient: createNotification.Recipient,
-			CreatedAt: fixedTime,
-			Sent:      false,
-			SentAt:    nil,
+			Id:          1,
+			Type:        createNotification.Type,
+			Recipient:   createNotification.Recipient,
+			Message:     createNotification.Message,
+			ScheduledAt: createNotification.ScheduledAt,
+			CreatedAt:   fixedTime,
+			Status:      notifications.StatusScheduled,
+			SentAt:      nil,
 		}
 
 		response := httptest.NewRecorder()
@@ -43,7 +58,7 @@ func TestSuccessCreate(t *testing.T) {
 		repository.On("CreateNotification", &createNotification).Return(int64(1), nil)
 		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
 
-		NewCreateHandler(repository).
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
 			Handler(response, request)
 
 		expectedStatusCode := http.StatusCreated
@@ -56,6 +71,69 @@ func TestSuccessCreate(t *testing.T) {
 	})
 }
 
+func TestRecipientNormalizationOnCreate(t *testing.T) {
+	t.Run("Should normalize the recipient before storing it", func(t *testing.T) {
+		scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+		body := `{"type":"email","recipient":"Test@Example.COM","message":"Hello","scheduled_at":"` + scheduledAt + `"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return createNotification.Recipient == "test@example.com"
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+}
+
+func TestLocaleOnCreate(t *testing.T) {
+	t.Run("Should store the locale in its canonical form", func(t *testing.T) {
+		scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+		body := `{"type":"sms","recipient":"+5511999999999","message":"Olá","locale":"pt-br","scheduled_at":"` + scheduledAt + `"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return createNotification.Locale == "pt-BR"
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+}
+
+func TestPriorityOnCreate(t *testing.T) {
+	t.Run("Should store the priority of the notification", func(t *testing.T) {
+		scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+		body := `{"type":"sms","recipient":"+5511999999999","message":"Your code is 123456","priority":"critical","scheduled_at":"` + scheduledAt + `"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return createNotification.Priority == "critical"
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1, Priority: "critical"}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+}
+
 func TestInvalidBodyOnCreate(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -72,7 +150,7 @@ func TestInvalidBodyOnCreate(t *testing.T) {
 
 			repository := mocks.NewRepository(t)
 
-			NewCreateHandler(repository).
+			NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
 				Handler(response, request)
 
 			expectedStatusCode := http.StatusBadRequest
@@ -87,14 +165,24 @@ func TestInvalidBodyOnCreate(t *testing.T) {
 }
 
 func TestValidationErrorOnCreate(t *testing.T) {
+	scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	pastScheduledAt := time.Now().Add(-time.Hour).Format(time.RFC3339)
+
 	testCases := []struct {
 		name                 string
 		expectedBodyContains string
 		body                 io.Reader
 	}{
-		{"Should return 422 when invalid request body (missing type)", `\"type\"`, io.NopCloser(strings.NewReader(`{"recipient":"1234567890"}`))},
-		{"Should return 422 when invalid request body (invalid type)", `\"type\"`, io.NopCloser(strings.NewReader(`{"type":"invalid","recipient":"1234567890"}`))},
-		{"Should return 422 when invalid request body (missing recipient)", `\"recipient\"`, io.NopCloser(strings.NewReader(`{"type":"sms"}`))},
+		{"Should return 422 when invalid request body (missing type)", `\"type\"`, io.NopCloser(strings.NewReader(`{"recipient":"+5511999999999","message":"Hello","scheduled_at":"` + scheduledAt + `"}`))},
+		{"Should return 422 when invalid request body (invalid type)", `\"type\"`, io.NopCloser(strings.NewReader(`{"type":"invalid","recipient":"+5511999999999","message":"Hello","scheduled_at":"` + scheduledAt + `"}`))},
+		{"Should return 422 when invalid request body (missing recipient)", `\"recipient\"`, io.NopCloser(strings.NewReader(`{"type":"sms","message":"Hello","scheduled_at":"` + scheduledAt + `"}`))},
+		{"Should return 422 when invalid request body (missing message)", `\"message\"`, io.NopCloser(strings.NewReader(`{"type":"sms","recipient":"+5511999999999","scheduled_at":"` + scheduledAt + `"}`))},
+		{"Should return 422 when invalid request body (missing scheduled_at)", `\"scheduled_at\"`, io.NopCloser(strings.NewReader(`{"type":"sms","recipient":"+5511999999999","message":"Hello"}`))},
+		{"Should return 422 when invalid request body (invalid phone number for sms)", `\"recipient\"`, io.NopCloser(strings.NewReader(`{"type":"sms","recipient":"test@example.com","message":"Hello","scheduled_at":"` + scheduledAt + `"}`))},
+		{"Should return 422 when invalid request body (invalid e-mail address)", `\"recipient\"`, io.NopCloser(strings.NewReader(`{"type":"email","recipient":"abc","message":"Hello","scheduled_at":"` + scheduledAt + `"}`))},
+		{"Should return 422 when invalid request body (invalid priority)", `\"priority\"`, io.NopCloser(strings.NewReader(`{"type":"sms","recipient":"+5511999999999","message":"Hello","priority":"urgent","scheduled_at":"` + scheduledAt + `"}`))},
+		{"Should return 422 when invalid request body (invalid locale)", `\"locale\"`, io.NopCloser(strings.NewReader(`{"type":"sms","recipient":"+5511999999999","message":"Hello","locale":"portuguese","scheduled_at":"` + scheduledAt + `"}`))},
+		{"Should return 422 when invalid request body (scheduled_at in the past)", `\"scheduled_at\"`, io.NopCloser(strings.NewReader(`{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"` + pastScheduledAt + `"}`))},
 	}
 
 	for _, tc := range testCases {
@@ -104,7 +192,670 @@ func TestValidationErrorOnCreate(t *testing.T) {
 
 			repository := mocks.NewRepository(t)
 
-			NewCreateHandler(repository).
+			NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+				Handler(response, request)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
+
+func TestIdempotencyKeyOnCreate(t *testing.T) {
+	fixedTime := time.Now().UTC()
+	scheduledAt := fixedTime.Add(time.Hour).Truncate(time.Second)
+	body := `{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"` + scheduledAt.Format(time.RFC3339) + `"}`
+
+	notification := notifications.Notification{
+		Id:          1,
+		Type:        "sms",
+		Recipient:   "+5511999999999",
+		Message:     "Hello",
+		ScheduledAt: scheduledAt,
+		CreatedAt:   fixedTime,
+		Status:      notifications.StatusScheduled,
+	}
+
+	isOrderKey := mock.MatchedBy(func(idempotencyKey *notifications.IdempotencyKey) bool {
+		return idempotencyKey.ClientId == "checkout" && idempotencyKey.Key == "order-42" && len(idempotencyKey.RequestHash) == 64
+	})
+
+	testCases := []struct {
+		name             string
+		existingId       int64
+		replayed         bool
+		expectedReplayed string
+	}{
+		{"Should create a notification with a new idempotency key", 0, false, ""},
+		{"Should return the original notification when the idempotency key is replayed", 1, true, "true"},
+		{"Should return the notification created concurrently with the same idempotency key", 0, true, "true"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+			request.Header.Set("Idempotency-Key", "order-42")
+			request.Header.Set("X-Client-Id", "checkout")
+
+			repository := mocks.NewRepository(t)
+			repository.On("FindIdempotentNotification", isOrderKey).Return(tc.existingId, nil)
+			repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+
+			if tc.existingId == 0 {
+				repository.On("CreateNotificationWithIdempotencyKey", isOrderKey, mock.Anything).Return(int64(1), tc.replayed, nil)
+			}
+
+			NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(notification)
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, http.StatusCreated, response.Code)
+			assert.Equal(t, tc.expectedReplayed, response.Header().Get("Idempotent-Replayed"))
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+
+	t.Run("Should replay the original notification after its scheduled time has passed", func(t *testing.T) {
+		pastBody := `{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"2020-01-01T10:00:00Z"}`
+		pastHash := sha256.Sum256([]byte(pastBody))
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(pastBody))
+		request.Header.Set("Idempotency-Key", "order-42")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindIdempotentNotification", &notifications.IdempotencyKey{Key: "order-42", RequestHash: hex.EncodeToString(pastHash[:])}).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, "true", response.Header().Get("Idempotent-Replayed"))
+	})
+
+	t.Run("Should return 422 when the idempotency key is reused with a different body", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+		request.Header.Set("Idempotency-Key", "order-42")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindIdempotentNotification", mock.Anything).Return(int64(0), notifications.ErrIdempotencyKeyReused)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Contains(t, response.Body.String(), `\"Idempotency-Key\"`)
+	})
+
+	t.Run("Should return 422 when the idempotency key is reused concurrently with a different body", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+		request.Header.Set("Idempotency-Key", "order-42")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindIdempotentNotification", mock.Anything).Return(int64(0), nil)
+		repository.On("CreateNotificationWithIdempotencyKey", mock.Anything, mock.Anything).Return(int64(0), false, notifications.ErrIdempotencyKeyReused)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Contains(t, response.Body.String(), `\"Idempotency-Key\"`)
+	})
+
+	t.Run("Should return 400 when the idempotency key is too long", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+		request.Header.Set("Idempotency-Key", strings.Repeat("a", 256))
+
+		repository := mocks.NewRepository(t)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errInvalidIdempotencyKey))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestTemplateOnCreate(t *testing.T) {
+	scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	template := &templates.Template{
+		Id:        7,
+		Version:   3,
+		Body:      "Olá, {{.name}}! Seu pedido {{.order}} saiu para entrega.",
+		Variables: []string{"name", "order"},
+	}
+
+	t.Run("Should create a notification pinned to the current template version", func(t *testing.T) {
+		body := `{"type":"sms","recipient":"+5511999999999","template_id":7,"variables":{"name":"Maria","order":42},"scheduled_at":"` + scheduledAt + `"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return *createNotification.TemplateId == 7 && createNotification.TemplateVersion == 3
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1}, nil)
+
+		templateRepository := templateMocks.NewRepository(t)
+		templateRepository.On("FindTemplateByID", int64(7)).Return(template, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateRepository, unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+
+	testCases := []struct {
+		name                 string
+		body                 string
+		template             *templates.Template
+		expectedBodyContains string
+	}{
+		{"Should return 422 when template does not exist", `{"type":"sms","recipient":"+5511999999999","template_id":7,"scheduled_at":"` + scheduledAt + `"}`, nil, `\"template_id\"`},
+		{"Should return 422 when template variables are missing", `{"type":"sms","recipient":"+5511999999999","template_id":7,"variables":{"name":"Maria"},"scheduled_at":"` + scheduledAt + `"}`, template, `\"variables\" must have the keys: order`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications", strings.NewReader(tc.body))
+
+			templateRepository := templateMocks.NewRepository(t)
+			templateRepository.On("FindTemplateByID", int64(7)).Return(tc.template, nil)
+
+			NewCreateHandler(mocks.NewRepository(t), prepare.NewPreparer(mocks.NewRepository(t), templateRepository, unsuppressed(t), contactMocks.NewRepository(t), nil)).
+				Handler(response, request)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+
+	t.Run("Should validate the variables against the localized template", func(t *testing.T) {
+		localized := *template
+		localized.Locales = templates.Locales{"pt": {Body: "Olá, {{.name}}! Pedido {{.orderNumber}}."}}
+
+		body := `{"type":"sms","recipient":"+5511999999999","template_id":7,"variables":{"name":"Maria","order":42},"locale":"pt-br","scheduled_at":"` + scheduledAt + `"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		templateRepository := templateMocks.NewRepository(t)
+		templateRepository.On("FindTemplateByID", int64(7)).Return(&localized, nil)
+
+		NewCreateHandler(mocks.NewRepository(t), prepare.NewPreparer(mocks.NewRepository(t), templateRepository, unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Contains(t, response.Body.String(), `\"variables\" can not render the template`)
+	})
+
+	t.Run("Should return 422 when both message and template are informed", func(t *testing.T) {
+		body := `{"type":"sms","recipient":"+5511999999999","message":"Hello","template_id":7,"scheduled_at":"` + scheduledAt + `"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		NewCreateHandler(mocks.NewRepository(t), prepare.NewPreparer(mocks.NewRepository(t), templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(&httputil.UnprocessableEntityError{Errors: []string{`Exactly one of the fields "message" or "template_id" must be informed`}})
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestExpirationOnCreate(t *testing.T) {
+	t.Run("Should create a notification with an expiry", func(t *testing.T) {
+		body := `{"type":"sms","recipient":"+5511999999999","message":"Your code is 123456","scheduled_at":"2030-01-07T12:00:00Z","expires_at":"2030-01-07T12:10:00Z"}`
+		expiresAt := time.Date(2030, time.January, 7, 12, 10, 0, 0, time.UTC)
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return createNotification.ExpiresAt != nil && createNotification.ExpiresAt.Equal(expiresAt)
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1, ExpiresAt: &expiresAt}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+
+	pastExpiresAt := time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+	testCases := []struct {
+		name                 string
+		fields               string
+		expectedBodyContains string
+	}{
+		{"Should return 422 when expires_at is in the past", `"expires_at":"` + pastExpiresAt + `"`, `\"expires_at\"`},
+		{"Should return 422 when expires_at is before scheduled_at", `"expires_at":"2030-01-07T11:00:00Z"`, `must be after \"scheduled_at\"`},
+		{"Should return 422 when the send window opens after expires_at", `"expires_at":"2030-01-07T12:10:00Z","send_window":{"start":"13:00","end":"21:00"}`, `can be sent within its send window`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"type":"sms","recipient":"+5511999999999","message":"Your code is 123456","scheduled_at":"2030-01-07T12:00:00Z",` + tc.fields + `}`
+
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+			NewCreateHandler(mocks.NewRepository(t), prepare.NewPreparer(mocks.NewRepository(t), templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+				Handler(response, request)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
+
+func TestFrequencyCapOnCreate(t *testing.T) {
+	scheduledAt := time.Date(2030, time.January, 7, 12, 0, 0, 0, time.UTC)
+	deliveries := []time.Time{scheduledAt.Add(-4 * time.Hour), scheduledAt.Add(-2 * time.Hour)}
+	nextAllowed := scheduledAt.Add(20 * time.Hour)
+
+	key := notifications.RecipientKey{Type: "sms", Recipient: "+5511999999999"}
+	since := map[notifications.RecipientKey]time.Time{key: scheduledAt.Add(-24 * time.Hour)}
+
+	capped := func(mode string) notifications.FrequencyCaps {
+		return notifications.FrequencyCaps{"sms": {Max: 2, Window: 24 * time.Hour, Mode: mode}}
+	}
+
+	t.Run("Should create a notification under the cap", func(t *testing.T) {
+		body := `{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"2030-01-07T12:00:00Z"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindRecipientsDeliveries", since).Return(map[notifications.RecipientKey][]time.Time{key: deliveries[:1]}, nil)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return createNotification.DeliverNotBefore.IsZero()
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapReject))).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+
+	t.Run("Should return 429 when the recipient is over the cap", func(t *testing.T) {
+		body := `{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"2030-01-07T12:00:00Z"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindRecipientsDeliveries", since).Return(map[notifications.RecipientKey][]time.Time{key: deliveries}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapReject))).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusTooManyRequests, response.Code)
+		assert.Contains(t, response.Body.String(), "the next allowed time is 2030-01-08T08:00:00Z")
+	})
+
+	t.Run("Should defer the notification to the next allowed time", func(t *testing.T) {
+		body := `{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"2030-01-07T12:00:00Z"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindRecipientsDeliveries", since).Return(map[notifications.RecipientKey][]time.Time{key: deliveries}, nil)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return createNotification.ScheduledAt.Equal(scheduledAt) && createNotification.DeliverNotBefore.Equal(nextAllowed)
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1, DeliverNotBefore: nextAllowed}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapDefer))).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+
+	t.Run("Should defer the notification to its send window after the cap", func(t *testing.T) {
+		body := `{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"2030-01-07T12:00:00Z","send_window":{"start":"09:00","end":"18:00"}}`
+		deliverNotBefore := time.Date(2030, time.January, 8, 9, 0, 0, 0, time.UTC)
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindRecipientsDeliveries", since).Return(map[notifications.RecipientKey][]time.Time{key: deliveries}, nil)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return createNotification.DeliverNotBefore.Equal(deliverNotBefore)
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1, DeliverNotBefore: deliverNotBefore}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapDefer))).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+
+	t.Run("Should return the original notification when the idempotency key is replayed over the cap", func(t *testing.T) {
+		body := `{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"2030-01-07T12:00:00Z"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+		request.Header.Set("Idempotency-Key", "order-42")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindIdempotentNotification", mock.Anything).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapReject))).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, "true", response.Header().Get("Idempotent-Replayed"))
+		repository.AssertNotCalled(t, "FindRecipientsDeliveries", mock.Anything)
+	})
+
+	t.Run("Should return 429 when the notification expires before the next allowed time", func(t *testing.T) {
+		body := `{"type":"sms","recipient":"+5511999999999","message":"Hello","scheduled_at":"2030-01-07T12:00:00Z","expires_at":"2030-01-07T18:00:00Z"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindRecipientsDeliveries", since).Return(map[notifications.RecipientKey][]time.Time{key: deliveries}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapDefer))).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusTooManyRequests, response.Code)
+	})
+}
+
+func unsuppressed(t *testing.T) *suppressionMocks.Repository {
+	suppressionRepository := suppressionMocks.NewRepository(t)
+	suppressionRepository.On("FindSuppressed", mock.Anything).Return(map[suppressions.Key]string{}, nil).Maybe()
+
+	return suppressionRepository
+}
+
+func TestSuppressionOnCreate(t *testing.T) {
+	t.Run("Should return 422 when the recipient is suppressed for the type", func(t *testing.T) {
+		scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+		body := `{"type":"email","recipient":"Test@Example.com","message":"Hello","scheduled_at":"` + scheduledAt + `"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		key := suppressions.Key{Type: "email", Recipient: "test@example.com"}
+
+		suppressionRepository := suppressionMocks.NewRepository(t)
+		suppressionRepository.On("FindSuppressed", []suppressions.Key{key}).Return(map[suppressions.Key]string{key: "unsubscribed"}, nil)
+
+		NewCreateHandler(mocks.NewRepository(t), prepare.NewPreparer(mocks.NewRepository(t), templateMocks.NewRepository(t), suppressionRepository, contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(&httputil.UnprocessableEntityError{
+			Errors: []string{`The field "recipient" is suppressed for "email" notifications (unsubscribed)`},
+		})
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestContactOnCreate(t *testing.T) {
+	scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	contactId := int64(3)
+	phone := "+5511999999999"
+	contact := &contacts.Contact{Id: contactId, Phone: &phone, PreferredChannels: []string{"whatsapp", "sms"}}
+
+	t.Run("Should resolve the type and recipient of the contact", func(t *testing.T) {
+		body := `{"contact_id":3,"message":"Hello","scheduled_at":"` + scheduledAt + `"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return createNotification.Type == "sms" &&
+				createNotification.Recipient == phone &&
+				*createNotification.ContactId == contactId
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1}, nil)
+
+		contactRepository := contactMocks.NewRepository(t)
+		contactRepository.On("FindContactByID", contactId).Return(contact, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactRepository, nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+
+	testCases := []struct {
+		name          string
+		body          string
+		contact       *contacts.Contact
+		expectedError string
+	}{
+		{"Should return 422 when both recipient and contact are informed", `{"type":"sms","recipient":"+5511999999999","contact_id":3`, nil, `Exactly one of the fields "recipient" or "contact_id" must be informed`},
+		{"Should return 422 when neither recipient nor contact are informed", `{"type":"sms"`, nil, `Exactly one of the fields "recipient" or "contact_id" must be informed`},
+		{"Should return 422 when the contact does not exist", `{"contact_id":3`, nil, `The field "contact_id" must reference an existing contact`},
+		{"Should return 422 when the contact has no recipient for the type", `{"type":"email","contact_id":3`, contact, `The field "contact_id" references a contact without a recipient for "email" notifications`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := tc.body + `,"message":"Hello","scheduled_at":"` + scheduledAt + `"}`
+
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+			contactRepository := contactMocks.NewRepository(t)
+			contactRepository.On("FindContactByID", contactId).Return(tc.contact, nil).Maybe()
+
+			NewCreateHandler(mocks.NewRepository(t), prepare.NewPreparer(mocks.NewRepository(t), templateMocks.NewRepository(t), unsuppressed(t), contactRepository, nil)).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(&httputil.UnprocessableEntityError{Errors: []string{tc.expectedError}})
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestChannelsOnCreate(t *testing.T) {
+	scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+
+	t.Run("Should start the chain on the first channel the contact has a recipient for", func(t *testing.T) {
+		phone := "+5511999999999"
+		contact := &contacts.Contact{Id: 3, Phone: &phone}
+		body := `{"contact_id":3,"channels":["push","whatsapp","sms"],"message":"Hello","scheduled_at":"` + scheduledAt + `"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return createNotification.Type == "sms" &&
+				createNotification.Recipient == phone &&
+				createNotification.ChannelPosition == 2 &&
+				assert.ObjectsAreEqual(notifications.Channels{
+					{Type: "push", Recipient: ""},
+					{Type: "whatsapp", Recipient: ""},
+					{Type: "sms", Recipient: phone},
+				}, createNotification.Chain)
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1}, nil)
+
+		contactRepository := contactMocks.NewRepository(t)
+		contactRepository.On("FindContactByID", int64(3)).Return(contact, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactRepository, nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+
+	t.Run("Should skip the channels the recipient is suppressed on", func(t *testing.T) {
+		body := `{"recipient":"+5511999999999","channels":["whatsapp","sms"],"message":"Hello","scheduled_at":"` + scheduledAt + `"}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+			return createNotification.Type == "sms" && createNotification.ChannelPosition == 1
+		})).Return(int64(1), nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1}, nil)
+
+		suppressed := suppressions.Key{Type: "whatsapp", Recipient: "+5511999999999"}
+
+		suppressionRepository := suppressionMocks.NewRepository(t)
+		suppressionRepository.On("FindSuppressed", mock.Anything).Return(map[suppressions.Key]string{suppressed: "unsubscribed"}, nil)
+
+		NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), suppressionRepository, contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+
+	testCases := []struct {
+		name                 string
+		fields               string
+		expectedBodyContains string
+	}{
+		{"Should return 422 when both type and channels are informed", `"type":"sms","channels":["sms"],"recipient":"+5511999999999"`, `Only one of the fields`},
+		{"Should return 422 when a channel is repeated", `"channels":["sms","sms"],"recipient":"+5511999999999"`, `must not repeat a type`},
+		{"Should return 422 when a channel is invalid", `"channels":["fax"],"recipient":"+5511999999999"`, `channels[0]`},
+		{"Should return 422 when the recipient is invalid for a channel", `"channels":["sms","email"],"recipient":"+5511999999999"`, `must be a valid e-mail address`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{` + tc.fields + `,"message":"Hello","scheduled_at":"` + scheduledAt + `"}`
+
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+			NewCreateHandler(mocks.NewRepository(t), prepare.NewPreparer(mocks.NewRepository(t), templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+				Handler(response, request)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
+
+func TestSendWindowOnCreate(t *testing.T) {
+	phone := "+5511999999999"
+	contact := &contacts.Contact{
+		Id:         3,
+		Phone:      &phone,
+		QuietHours: &contacts.QuietHours{Start: "22:00", End: "08:00", Timezone: "America/Sao_Paulo"},
+	}
+
+	successTestCases := []struct {
+		name                     string
+		fields                   string
+		scheduledAt              string
+		expectedDeliverNotBefore string
+	}{
+		{
+			"Should delay the delivery to the next send window",
+			`"recipient":"+5511999999999","send_window":{"start":"08:00","end":"21:00","weekdays":["mon","tue","wed","thu","fri"]},"timezone":"America/Sao_Paulo"`,
+			"2030-01-05T15:00:00Z",
+			"2030-01-07T11:00:00Z",
+		},
+		{
+			"Should delay the delivery past the quiet hours of the contact",
+			`"contact_id":3`,
+			"2030-01-07T02:00:00Z",
+			"2030-01-07T11:00:00Z",
+		},
+		{
+			"Should delay the delivery to a send window outside the quiet hours of the contact",
+			`"contact_id":3,"send_window":{"start":"07:00","end":"12:00"},"timezone":"America/Sao_Paulo"`,
+			"2030-01-07T02:00:00Z",
+			"2030-01-07T11:00:00Z",
+		},
+	}
+
+	for _, tc := range successTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"type":"sms",` + tc.fields + `,"message":"Hello","scheduled_at":"` + tc.scheduledAt + `"}`
+			expectedDeliverNotBefore, err := time.Parse(time.RFC3339, tc.expectedDeliverNotBefore)
+
+			require.Nilf(t, err, "Failed to parse time: %v", err)
+
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+			repository := mocks.NewRepository(t)
+			repository.On("CreateNotification", mock.MatchedBy(func(createNotification *notifications.CreateNotification) bool {
+				return createNotification.DeliverNotBefore.Equal(expectedDeliverNotBefore) &&
+					(createNotification.ContactId == nil || createNotification.QuietHours == contact.QuietHours)
+			})).Return(int64(1), nil)
+			repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1}, nil)
+
+			contactRepository := contactMocks.NewRepository(t)
+			contactRepository.On("FindContactByID", int64(3)).Return(contact, nil).Maybe()
+
+			NewCreateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactRepository, nil)).
+				Handler(response, request)
+
+			assert.Equal(t, http.StatusCreated, response.Code)
+		})
+	}
+
+	testCases := []struct {
+		name                 string
+		fields               string
+		expectedBodyContains string
+	}{
+		{"Should return 422 when the send window start is invalid", `"recipient":"+5511999999999","send_window":{"start":"8h","end":"21:00"}`, `send_window.start`},
+		{"Should return 422 when a weekday is invalid", `"recipient":"+5511999999999","send_window":{"start":"08:00","end":"21:00","weekdays":["monday"]}`, `send_window.weekdays[0]`},
+		{"Should return 422 when the send window is empty", `"recipient":"+5511999999999","send_window":{"start":"08:00","end":"08:00"}`, `must have different`},
+		{"Should return 422 when the timezone is invalid", `"recipient":"+5511999999999","send_window":{"start":"08:00","end":"21:00"},"timezone":"Mars/Olympus"`, `IANA time zone`},
+		{"Should return 422 when the timezone is informed without a send window", `"recipient":"+5511999999999","timezone":"America/Sao_Paulo"`, `requires the field`},
+		{"Should return 422 when the send window is inside the quiet hours of the contact", `"contact_id":3,"send_window":{"start":"23:00","end":"23:30"},"timezone":"America/Sao_Paulo"`, `never opens outside the quiet hours`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body := `{"type":"sms",` + tc.fields + `,"message":"Hello","scheduled_at":"2030-01-07T02:00:00Z"}`
+
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications", strings.NewReader(body))
+
+			contactRepository := contactMocks.NewRepository(t)
+			contactRepository.On("FindContactByID", int64(3)).Return(contact, nil).Maybe()
+
+			NewCreateHandler(mocks.NewRepository(t), prepare.NewPreparer(mocks.NewRepository(t), templateMocks.NewRepository(t), unsuppressed(t), contactRepository, nil)).
 				Handler(response, request)
 
 			assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
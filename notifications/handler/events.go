@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"net/http"
+)
+
+type EventsHandler struct {
+	notificationRepository notifications.Repository
+}
+
+func NewEventsHandler(notificationRepository notifications.Repository) *EventsHandler {
+	return &EventsHandler{notificationRepository: notificationRepository}
+}
+
+func (h *EventsHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	events, err := h.notificationRepository.FindNotificationEventsByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if events == nil {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.OkResponse(w, events)
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuccessEvents(t *testing.T) {
+	t.Run("Should return notification events successfully", func(t *testing.T) {
+		fixedTime := time.Now().UTC()
+		failure := "provider unavailable"
+
+		events := []*notifications.Event{
+			{Id: 1, CreatedAt: fixedTime, Event: notifications.EventCreated, Status: notifications.StatusScheduled, Actor: notifications.ActorAPI},
+			{Id: 2, CreatedAt: fixedTime, Event: "sending", Status: notifications.StatusSending, Actor: notifications.ActorDispatcher},
+			{Id: 3, CreatedAt: fixedTime, Event: "failed", Status: notifications.StatusFailed, Error: &failure, Actor: notifications.ActorDispatcher},
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/notifications/1/events", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationEventsByID", int64(1)).Return(events, nil)
+
+		NewEventsHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusOK
+		expectedBody, err := json.Marshal(events)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestNotFoundOnEvents(t *testing.T) {
+	t.Run("Should return 404 when notification not found", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/notifications/1/events", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationEventsByID", int64(1)).Return(nil, nil)
+
+		NewEventsHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusNotFound
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errNotFound))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestInvalidIdOnEvents(t *testing.T) {
+	t.Run("Should return 400 when id is invalid", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/notifications/invalid-id/events", nil)
+		request.SetPathValue("id", "invalid-id")
+
+		repository := mocks.NewRepository(t)
+
+		NewEventsHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusBadRequest
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errInvalidOrMissingId))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
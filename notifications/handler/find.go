@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"net/http"
+)
+
+type FindHandler struct {
+	notificationRepository notifications.Repository
+}
+
+func NewFindHandler(notificationRepository notifications.Repository) *FindHandler {
+	return &FindHandler{notificationRepository: notificationRepository}
+}
+
+func (h *FindHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	notification, err := h.notificationRepository.FindNotificationByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if notification == nil {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.OkResponse(w, notification)
+}
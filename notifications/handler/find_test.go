@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuccessFind(t *testing.T) {
+	t.Run("Should return notification successfully", func(t *testing.T) {
+		fixedTime := time.Now().UTC()
+		lastError := "provider unavailable"
+
+		notification := notifications.Notification{
+			Id:            1,
+			CreatedAt:     fixedTime,
+			Type:          "sms",
+			Recipient:     "1234567890",
+			Message:       "Hello",
+			ScheduledAt:   fixedTime.Add(time.Hour),
+			Status:        notifications.StatusQueued,
+			Attempts:      1,
+			NextAttemptAt: &fixedTime,
+			LastError:     &lastError,
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/notifications/1", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+
+		NewFindHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusOK
+		expectedBody, err := json.Marshal(&notification)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestNotFoundOnFind(t *testing.T) {
+	t.Run("Should return 404 when notification not found", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/notifications/1", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(nil, nil)
+
+		NewFindHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusNotFound
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errNotFound))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestInvalidIdOnFind(t *testing.T) {
+	t.Run("Should return 400 when id is invalid", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/notifications/invalid-id", nil)
+		request.SetPathValue("id", "invalid-id")
+
+		repository := mocks.NewRepository(t)
+
+		NewFindHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusBadRequest
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errInvalidOrMissingId))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"github.com/Oudwins/zog"
+	"github.com/Oudwins/zog/zhttp"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"net/http"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 500
+)
+
+var listNotificationsSchema = zog.Struct(zog.Schema{
+	"type":                    zog.String().Trim().OneOf(notifications.Types),
+	"recipient":               zog.String().Trim().Max(255),
+	"contactId":               zog.Int64().GT(0),
+	"recurringNotificationId": zog.Int64().GT(0),
+	"status":                  zog.String().Trim().OneOf(notifications.Statuses),
+	"createdFrom":             zog.Time(),
+	"createdTo":               zog.Time(),
+	"scheduledFrom":           zog.Time(),
+	"scheduledTo":             zog.Time(),
+	"cursor":                  zog.Int64().GT(0),
+	"limit":                   zog.Int().Default(defaultListLimit).GT(0).LTE(maxListLimit),
+})
+
+type ListHandler struct {
+	notificationRepository notifications.Repository
+}
+
+func NewListHandler(notificationRepository notifications.Repository) *ListHandler {
+	return &ListHandler{notificationRepository: notificationRepository}
+}
+
+func (h *ListHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var filter notifications.ListNotificationsFilter
+
+	validationErrors := listNotificationsSchema.Parse(zhttp.Request(r), &filter)
+
+	if validationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(validationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	if filter.Type != "" && filter.Recipient != "" {
+		recipient, err := notifications.NormalizeRecipient(filter.Type, filter.Recipient)
+
+		if err == nil {
+			filter.Recipient = recipient
+		}
+	}
+
+	page, err := h.notificationRepository.ListNotifications(&filter)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.OkResponse(w, page)
+}
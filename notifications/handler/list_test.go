@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuccessList(t *testing.T) {
+	createdFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	nextCursor := int64(11)
+
+	testCases := []struct {
+		name           string
+		query          string
+		expectedFilter notifications.ListNotificationsFilter
+	}{
+		{
+			"Should list notifications with default limit",
+			"",
+			notifications.ListNotificationsFilter{Limit: defaultListLimit},
+		},
+		{
+			"Should list notifications with filters",
+			"?type=sms&recipient=%2B55%2011%2099999-9999&status=sent&created_from=2025-01-01T00:00:00Z&cursor=12&limit=1",
+			notifications.ListNotificationsFilter{
+				Type:        "sms",
+				Recipient:   "+5511999999999",
+				Status:      "sent",
+				CreatedFrom: createdFrom,
+				Cursor:      12,
+				Limit:       1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			page := &notifications.NotificationPage{
+				Data: []*notifications.Notification{
+					{Id: 11, Type: "sms", Recipient: "+5511999999999", Status: notifications.StatusSent, CreatedAt: createdFrom, ScheduledAt: createdFrom},
+				},
+				NextCursor: &nextCursor,
+			}
+
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("GET", "/notifications"+tc.query, nil)
+
+			repository := mocks.NewRepository(t)
+			repository.On("ListNotifications", &tc.expectedFilter).Return(page, nil)
+
+			NewListHandler(repository).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(page)
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestValidationErrorOnList(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		query                string
+		expectedBodyContains string
+	}{
+		{"Should return 422 when type is invalid", "?type=invalid", `\"type\"`},
+		{"Should return 422 when status is invalid", "?status=invalid", `\"status\"`},
+		{"Should return 422 when date is invalid", "?scheduled_from=yesterday", `\"scheduled_from\"`},
+		{"Should return 422 when limit is too large", "?limit=1000", `\"limit\"`},
+		{"Should return 422 when cursor is invalid", "?cursor=abc", `\"cursor\"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("GET", "/notifications"+tc.query, nil)
+
+			repository := mocks.NewRepository(t)
+
+			NewListHandler(repository).
+				Handler(response, request)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Tagliatti/magalu-challenge/channels"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"net/http"
+	"strings"
+)
+
+type preview struct {
+	Type     string            `json:"type"`
+	Subject  *string           `json:"subject"`
+	Body     string            `json:"body"`
+	HtmlBody *string           `json:"html_body"`
+	SMS      *channels.SMSInfo `json:"sms,omitempty"`
+	Warnings []string          `json:"warnings"`
+}
+
+type PreviewHandler struct {
+	templateRepository templates.Repository
+	preparer           *prepare.Preparer
+}
+
+func NewPreviewHandler(templateRepository templates.Repository, preparer *prepare.Preparer) *PreviewHandler {
+	return &PreviewHandler{
+		templateRepository: templateRepository,
+		preparer:           preparer,
+	}
+}
+
+// Handler renders a notification exactly as it would be sent, without storing it.
+// Template variables that were not informed are replaced by a sample value.
+func (h *PreviewHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var createNotification *notifications.CreateNotification
+	err := json.NewDecoder(r.Body).Decode(&createNotification)
+
+	if err != nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
+	if createNotification == nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
+	validationError, err := h.preparer.Resolve(createNotification)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if validationError != nil {
+		httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{Errors: validationError.Errors})
+		return
+	}
+
+	result := &preview{
+		Type:     createNotification.Type,
+		Body:     createNotification.Message,
+		Warnings: make([]string, 0),
+	}
+
+	if createNotification.TemplateId != nil {
+		template, err := h.templateRepository.FindTemplateByID(*createNotification.TemplateId)
+
+		if err != nil {
+			httputil.InternalServerErrorResponse(w, err)
+			return
+		}
+
+		if template == nil {
+			httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{Errors: []string{prepare.ErrTemplateNotFound.Error()}})
+			return
+		}
+
+		localized := template.Localized(createNotification.Locale)
+
+		if createNotification.Locale != "" && localized == template {
+			result.Warnings = append(result.Warnings, `The template has no variant for the locale "`+createNotification.Locale+`", the default content was used`)
+		}
+
+		variables := make(map[string]any, len(createNotification.Variables))
+
+		for name, value := range createNotification.Variables {
+			variables[name] = value
+		}
+
+		for _, name := range template.MissingVariables(createNotification.Variables) {
+			variables[name] = "[" + name + "]"
+			result.Warnings = append(result.Warnings, `The variable "`+name+`" was not informed, the sample value "[`+name+`]" was used`)
+		}
+
+		rendered, err := localized.Render(variables)
+
+		if err != nil {
+			httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{
+				Errors: []string{`The field "variables" can not render the template: ` + err.Error()},
+			})
+			return
+		}
+
+		result.Body = rendered.Body
+
+		if localized.Subject != nil {
+			result.Subject = &rendered.Subject
+		}
+
+		if localized.HtmlBody != nil {
+			result.HtmlBody = &rendered.HtmlBody
+		}
+	}
+
+	if createNotification.Type == "sms" {
+		result.SMS = channels.AnalyzeSMS(result.Body)
+
+		if result.SMS.Encoding == channels.EncodingUCS2 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				`The characters "%s" are not in the GSM-7 alphabet, so the message is sent as UCS-2 with up to 70 characters per segment instead of 160`,
+				strings.Join(result.SMS.NonGSMCharacters, `", "`),
+			))
+		}
+
+		if result.SMS.Segments > 1 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("The message is split into %d SMS segments", result.SMS.Segments))
+		}
+	}
+
+	httputil.OkResponse(w, result)
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/channels"
+	contactMocks "github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	"github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	templateMocks "github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPreview(t *testing.T) {
+	scheduledAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	subject := "Pedido {{.order}}"
+	renderedSubject := "Pedido 42"
+	template := &templates.Template{
+		Id:        7,
+		Version:   1,
+		Subject:   &subject,
+		Body:      "Hello, {{.name}}! Order {{.order}} shipped.",
+		Variables: []string{"name", "order"},
+		Locales: templates.Locales{
+			"pt": {Body: "Olá, {{.name}}! Pedido {{.order}} enviado."},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		body     string
+		template *templates.Template
+		expected *preview
+	}{
+		{
+			"Should analyze the segments of a plain SMS",
+			`{"type":"sms","recipient":"+5511999999999","message":"` + strings.Repeat("a", 200) + `","scheduled_at":"` + scheduledAt + `"}`,
+			nil,
+			&preview{
+				Type:     "sms",
+				Body:     strings.Repeat("a", 200),
+				SMS:      &channels.SMSInfo{Encoding: channels.EncodingGSM7, Length: 200, Segments: 2},
+				Warnings: []string{"The message is split into 2 SMS segments"},
+			},
+		},
+		{
+			"Should render the localized template with sample variables",
+			`{"type":"sms","recipient":"+5511999999999","template_id":7,"variables":{"name":"Maria"},"locale":"pt-BR","scheduled_at":"` + scheduledAt + `"}`,
+			template,
+			&preview{
+				Type: "sms",
+				Body: "Olá, Maria! Pedido [order] enviado.",
+				SMS:  &channels.SMSInfo{Encoding: channels.EncodingUCS2, Length: 35, Segments: 1, NonGSMCharacters: []string{"á"}},
+				Warnings: []string{
+					`The variable "order" was not informed, the sample value "[order]" was used`,
+					`The characters "á" are not in the GSM-7 alphabet, so the message is sent as UCS-2 with up to 70 characters per segment instead of 160`,
+				},
+			},
+		},
+		{
+			"Should render the e-mail subject of the default content",
+			`{"type":"email","recipient":"test@example.com","template_id":7,"variables":{"name":"Maria","order":42},"locale":"en-US","scheduled_at":"` + scheduledAt + `"}`,
+			template,
+			&preview{
+				Type:     "email",
+				Subject:  &renderedSubject,
+				Body:     "Hello, Maria! Order 42 shipped.",
+				Warnings: []string{`The template has no variant for the locale "en-US", the default content was used`},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications/preview", strings.NewReader(tc.body))
+
+			templateRepository := templateMocks.NewRepository(t)
+
+			if tc.template != nil {
+				templateRepository.On("FindTemplateByID", int64(7)).Return(tc.template, nil)
+			}
+
+			NewPreviewHandler(templateRepository, prepare.NewPreparer(mocks.NewRepository(t), templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(tc.expected)
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+}
+
+func TestInvalidRequestOnPreview(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		body                 string
+		expectedStatusCode   int
+		expectedBodyContains string
+	}{
+		{"Should return 400 when body is invalid", `invalid`, http.StatusBadRequest, errInvalidBody.Error()},
+		{"Should return 400 when body is null", `null`, http.StatusBadRequest, errInvalidBody.Error()},
+		{"Should return 422 when payload is invalid", `{"type":"fax","recipient":"+5511999999999","message":"Hello"}`, http.StatusUnprocessableEntity, `\"type\"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications/preview", strings.NewReader(tc.body))
+
+			NewPreviewHandler(templateMocks.NewRepository(t), prepare.NewPreparer(mocks.NewRepository(t), templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+				Handler(response, request)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
@@ -7,15 +7,15 @@ import (
 	"net/http"
 )
 
-type DeleteHandler struct {
+type PurgeHandler struct {
 	notificationRepository notifications.Repository
 }
 
-func NewDeleteHandler(notificationRepository notifications.Repository) *DeleteHandler {
-	return &DeleteHandler{notificationRepository: notificationRepository}
+func NewPurgeHandler(notificationRepository notifications.Repository) *PurgeHandler {
+	return &PurgeHandler{notificationRepository: notificationRepository}
 }
 
-func (h *DeleteHandler) Handler(w http.ResponseWriter, r *http.Request) {
+func (h *PurgeHandler) Handler(w http.ResponseWriter, r *http.Request) {
 	var id int64
 
 	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
@@ -29,6 +29,7 @@ func (h *DeleteHandler) Handler(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		httputil.InternalServerErrorResponse(w, err)
+		return
 	}
 
 	if !found {
@@ -12,16 +12,16 @@ import (
 	"testing"
 )
 
-func TestSuccessDelete(t *testing.T) {
+func TestSuccessPurge(t *testing.T) {
 	t.Run("Should delete a notification successfully", func(t *testing.T) {
 		response := httptest.NewRecorder()
-		request := httptest.NewRequest("DELETE", "/notifications/1", nil)
+		request := httptest.NewRequest("DELETE", "/admin/notifications/1", nil)
 		request.SetPathValue("id", "1")
 
 		repository := mocks.NewRepository(t)
 		repository.On("DeleteNotificationByID", int64(1)).Return(true, nil)
 
-		NewDeleteHandler(repository).
+		NewPurgeHandler(repository).
 			Handler(response, request)
 
 		expectedStatusCode := http.StatusNoContent
@@ -31,16 +31,16 @@ func TestSuccessDelete(t *testing.T) {
 	})
 }
 
-func TestNotFoundOnDelete(t *testing.T) {
+func TestNotFoundOnPurge(t *testing.T) {
 	t.Run("Should return 404 when notification not found", func(t *testing.T) {
 		response := httptest.NewRecorder()
-		request := httptest.NewRequest("DELETE", "/notifications/1", nil)
+		request := httptest.NewRequest("DELETE", "/admin/notifications/1", nil)
 		request.SetPathValue("id", "1")
 
 		repository := mocks.NewRepository(t)
 		repository.On("DeleteNotificationByID", int64(1)).Return(false, nil)
 
-		NewDeleteHandler(repository).
+		NewPurgeHandler(repository).
 			Handler(response, request)
 
 		expectedStatusCode := http.StatusNotFound
@@ -53,15 +53,15 @@ func TestNotFoundOnDelete(t *testing.T) {
 	})
 }
 
-func TestInvalidIdOnDelete(t *testing.T) {
+func TestInvalidIdOnPurge(t *testing.T) {
 	t.Run("Should return 400 when id is invalid", func(t *testing.T) {
 		response := httptest.NewRecorder()
-		request := httptest.NewRequest("DELETE", "/notifications/invalid-id", nil)
+		request := httptest.NewRequest("DELETE", "/admin/notifications/invalid-id", nil)
 		request.SetPathValue("id", "invalid-id")
 
 		repository := mocks.NewRepository(t)
 
-		NewDeleteHandler(repository).
+		NewPurgeHandler(repository).
 			Handler(response, request)
 
 		expectedStatusCode := http.StatusBadRequest
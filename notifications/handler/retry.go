@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"net/http"
+	"time"
+)
+
+var errNotRetryable = errors.New("only failed notifications can be retried")
+
+type RetryHandler struct {
+	notificationRepository notifications.Repository
+}
+
+func NewRetryHandler(notificationRepository notifications.Repository) *RetryHandler {
+	return &RetryHandler{notificationRepository: notificationRepository}
+}
+
+func (h *RetryHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	notification, err := h.notificationRepository.FindNotificationByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if notification == nil {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	// A pending notification re-queued now would be sent on the next poll,
+	// ignoring its schedule, send window and frequency cap.
+	if !notification.Status.CanTransitionTo(notifications.StatusQueued) {
+		httputil.ConflictResponse(w, errNotRetryable)
+		return
+	}
+
+	found, err := h.notificationRepository.RetryNotificationByID(id, notification.NextSendTime(time.Now()))
+
+	if err != nil {
+		if errors.Is(err, notifications.ErrInvalidStatusTransition) {
+			httputil.ConflictResponse(w, errNotRetryable)
+			return
+		}
+
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !found {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.NoContentResponse(w)
+}
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuccessRetry(t *testing.T) {
+	t.Run("Should re-queue a failed notification successfully", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications/1/retry", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1, Status: notifications.StatusFailed}, nil)
+		repository.On("RetryNotificationByID", int64(1), mock.AnythingOfType("time.Time")).Return(true, nil)
+
+		NewRetryHandler(repository).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		assert.Equal(t, "", response.Body.String())
+	})
+
+	t.Run("Should delay the retry past the quiet hours of the notification", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications/1/retry", nil)
+		request.SetPathValue("id", "1")
+
+		// Quiet all day except for the minute before now.
+		opening := time.Now().UTC().Add(-time.Minute)
+		quietHours := &notifications.QuietHours{Start: opening.Add(time.Minute).Format("15:04"), End: opening.Format("15:04"), Timezone: "UTC"}
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1, Status: notifications.StatusFailed, QuietHours: quietHours}, nil)
+		repository.On("RetryNotificationByID", int64(1), mock.MatchedBy(func(nextAttemptAt time.Time) bool {
+			return nextAttemptAt.After(time.Now().Add(23 * time.Hour))
+		})).Return(true, nil)
+
+		NewRetryHandler(repository).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+}
+
+func TestConflictOnRetry(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status notifications.Status
+	}{
+		{"Should return 409 when notification was sent", notifications.StatusSent},
+		{"Should return 409 when notification is scheduled", notifications.StatusScheduled},
+		{"Should return 409 when notification is queued", notifications.StatusQueued},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/notifications/1/retry", nil)
+			request.SetPathValue("id", "1")
+
+			repository := mocks.NewRepository(t)
+			repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1, Status: tc.status}, nil)
+
+			NewRetryHandler(repository).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(httputil.NewErrorMessage(errNotRetryable))
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, http.StatusConflict, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+			repository.AssertNotCalled(t, "RetryNotificationByID", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Should return 409 when notification stops being failed before the retry", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications/1/retry", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notifications.Notification{Id: 1, Status: notifications.StatusFailed}, nil)
+		repository.On("RetryNotificationByID", int64(1), mock.AnythingOfType("time.Time")).Return(false, notifications.ErrInvalidStatusTransition)
+
+		NewRetryHandler(repository).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+	})
+}
+
+func TestNotFoundOnRetry(t *testing.T) {
+	t.Run("Should return 404 when notification not found", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications/1/retry", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(nil, nil)
+
+		NewRetryHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusNotFound
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errNotFound))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestInvalidIdOnRetry(t *testing.T) {
+	t.Run("Should return 400 when id is invalid", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/notifications/invalid-id/retry", nil)
+		request.SetPathValue("id", "invalid-id")
+
+		repository := mocks.NewRepository(t)
+
+		NewRetryHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusBadRequest
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errInvalidOrMissingId))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
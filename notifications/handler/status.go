@@ -13,10 +13,11 @@ var errInvalidOrMissingId = errors.New("invalid or missing notification id")
 
 type StatusHandler struct {
 	notificationRepository notifications.Repository
+	frequencyCaps          notifications.FrequencyCaps
 }
 
-func NewStatusHandler(notificationRepository notifications.Repository) *StatusHandler {
-	return &StatusHandler{notificationRepository: notificationRepository}
+func NewStatusHandler(notificationRepository notifications.Repository, frequencyCaps notifications.FrequencyCaps) *StatusHandler {
+	return &StatusHandler{notificationRepository: notificationRepository, frequencyCaps: frequencyCaps}
 }
 
 func (h *StatusHandler) Handler(w http.ResponseWriter, r *http.Request) {
@@ -41,5 +42,22 @@ func (h *StatusHandler) Handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if frequencyCap, ok := h.frequencyCaps.For(notification.Type); ok {
+		delivery := notification.DeliverNotBefore
+
+		if notification.SentAt != nil {
+			delivery = *notification.SentAt
+		}
+
+		deliveries, err := h.notificationRepository.FindRecipientDeliveries(notification.Type, notification.Recipient, delivery.Add(-frequencyCap.Window), id)
+
+		if err != nil {
+			httputil.InternalServerErrorResponse(w, err)
+			return
+		}
+
+		notification.FrequencyCap = frequencyCap.Usage(deliveries, delivery)
+	}
+
 	httputil.OkResponse(w, &notification)
 }
@@ -22,17 +22,21 @@ func TestSuccessStatus(t *testing.T) {
 		status notifications.NotificationStatus
 	}{
 		{
-			"Should return notification status successfully (sent)",
+			"Should return notification status successfully (scheduled)",
 			notifications.NotificationStatus{
-				Sent:   false,
-				SentAt: nil,
+				Status:      notifications.StatusScheduled,
+				CreatedAt:   fixedTime,
+				ScheduledAt: fixedTime.Add(time.Hour),
 			},
 		},
 		{
-			"Should return notification status successfully (not sent)",
+			"Should return notification status successfully (sent)",
 			notifications.NotificationStatus{
-				Sent:   true,
-				SentAt: &fixedTime,
+				Status:      notifications.StatusSent,
+				CreatedAt:   fixedTime,
+				ScheduledAt: fixedTime.Add(time.Hour),
+				SendingAt:   &fixedTime,
+				SentAt:      &fixedTime,
 			},
 		},
 	}
@@ -46,7 +50,7 @@ func TestSuccessStatus(t *testing.T) {
 			repository := mocks.NewRepository(t)
 			repository.On("FindNotificationStatusByID", int64(1)).Return(&tc.status, nil)
 
-			NewStatusHandler(repository).
+			NewStatusHandler(repository, nil).
 				Handler(response, request)
 
 			expectedStatusCode := http.StatusOK
@@ -60,6 +64,32 @@ func TestSuccessStatus(t *testing.T) {
 	}
 }
 
+func TestFrequencyCapOnStatus(t *testing.T) {
+	t.Run("Should return the frequency cap usage of the recipient", func(t *testing.T) {
+		sentAt := time.Date(2030, time.January, 7, 12, 0, 0, 0, time.UTC)
+		status := notifications.NotificationStatus{
+			Status:    notifications.StatusSent,
+			Type:      "push",
+			Recipient: "device-token",
+			SentAt:    &sentAt,
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/notifications/1/status", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationStatusByID", int64(1)).Return(&status, nil)
+		repository.On("FindRecipientDeliveries", "push", "device-token", sentAt.Add(-24*time.Hour), int64(1)).Return([]time.Time{sentAt.Add(-time.Hour), sentAt.Add(time.Hour)}, nil)
+
+		NewStatusHandler(repository, notifications.FrequencyCaps{"push": {Max: 3, Window: 24 * time.Hour, Mode: notifications.FrequencyCapReject}}).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"frequency_cap":{"max":3,"window":"24h0m0s","count":2}`)
+	})
+}
+
 func TestNotFoundOnStatus(t *testing.T) {
 	t.Run("Should return 404 when notification not found", func(t *testing.T) {
 		response := httptest.NewRecorder()
@@ -69,7 +99,7 @@ func TestNotFoundOnStatus(t *testing.T) {
 		repository := mocks.NewRepository(t)
 		repository.On("FindNotificationStatusByID", int64(1)).Return(nil, nil)
 
-		NewStatusHandler(repository).
+		NewStatusHandler(repository, nil).
 			Handler(response, request)
 
 		expectedStatusCode := http.StatusNotFound
@@ -90,7 +120,7 @@ func TestInvalidIdOnStatus(t *testing.T) {
 
 		repository := mocks.NewRepository(t)
 
-		NewStatusHandler(repository).
+		NewStatusHandler(repository, nil).
 			Handler(response, request)
 
 		expectedStatusCode := http.StatusBadRequest
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"net/http"
+)
+
+var updateNotificationSchema = zog.Struct(zog.Schema{
+	"recipient":   zog.Ptr(zog.String().Min(3).Max(255).Required()),
+	"message":     zog.Ptr(zog.String().Trim().Required().Max(4096)),
+	"scheduledAt": zog.Ptr(zog.Time().Required().TestFunc(httputil.IsInTheFuture, zog.Message("must be in the future"))),
+})
+
+var errNotEditable = errors.New("notification was already picked up for sending and can no longer be changed")
+var errNothingToUpdate = errors.New(`At least one of the fields "recipient", "message" or "scheduled_at" must be informed`)
+
+type UpdateHandler struct {
+	notificationRepository notifications.Repository
+	preparer               *prepare.Preparer
+}
+
+func NewUpdateHandler(notificationRepository notifications.Repository, preparer *prepare.Preparer) *UpdateHandler {
+	return &UpdateHandler{
+		notificationRepository: notificationRepository,
+		preparer:               preparer,
+	}
+}
+
+func (h *UpdateHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	var updateNotification notifications.UpdateNotification
+	err := json.NewDecoder(r.Body).Decode(&updateNotification)
+
+	if err != nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
+	bodyValidationErrors := updateNotificationSchema.Validate(&updateNotification)
+
+	if bodyValidationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(bodyValidationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	if updateNotification.Recipient == nil && updateNotification.Message == nil && updateNotification.ScheduledAt == nil {
+		httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{Errors: []string{errNothingToUpdate.Error()}})
+		return
+	}
+
+	if updateNotification.Recipient != nil || updateNotification.ScheduledAt != nil {
+		notification, err := h.notificationRepository.FindNotificationByID(id)
+
+		if err != nil {
+			httputil.InternalServerErrorResponse(w, err)
+			return
+		}
+
+		if notification == nil {
+			httputil.NotFoundResponse(w, errNotFound)
+			return
+		}
+
+		validationError, err := h.preparer.PrepareUpdate(notification, &updateNotification)
+
+		if err != nil {
+			httputil.InternalServerErrorResponse(w, err)
+			return
+		}
+
+		if validationError != nil && validationError.FrequencyCapped {
+			httputil.TooManyRequestsResponse(w, validationError)
+			return
+		}
+
+		if validationError != nil {
+			httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{Errors: validationError.Errors})
+			return
+		}
+	}
+
+	found, err := h.notificationRepository.UpdateNotificationByID(id, &updateNotification)
+
+	if err != nil {
+		if errors.Is(err, notifications.ErrNotificationNotPending) {
+			httputil.ConflictResponse(w, errNotEditable)
+			return
+		}
+
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !found {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	notification, err := h.notificationRepository.FindNotificationByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.OkResponse(w, notification)
+}
@@ -0,0 +1,405 @@
+package handler
+
+import (
+	"encoding/json"
+	contactMocks "github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	suppressionMocks "github.com/Tagliatti/magalu-challenge/suppressions/mocks"
+	templateMocks "github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuccessUpdate(t *testing.T) {
+	t.Run("Should reschedule a notification successfully", func(t *testing.T) {
+		fixedTime := time.Now().UTC()
+		scheduledAt := fixedTime.Add(2 * time.Hour).Truncate(time.Second)
+		body := `{"message":"Hello again","scheduled_at":"` + scheduledAt.Format(time.RFC3339) + `"}`
+
+		var updateNotification notifications.UpdateNotification
+
+		err := json.Unmarshal([]byte(body), &updateNotification)
+
+		require.Nilf(t, err, "Failed to unmarshal JSON: %v", err)
+
+		notification := notifications.Notification{
+			Id:          1,
+			Type:        "sms",
+			Recipient:   "1234567890",
+			Message:     *updateNotification.Message,
+			ScheduledAt: scheduledAt,
+			CreatedAt:   fixedTime,
+			Status:      notifications.StatusScheduled,
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(body))
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("UpdateNotificationByID", int64(1), &updateNotification).Return(true, nil)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusOK
+		expectedBody, err := json.Marshal(&notification)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestConflictOnUpdate(t *testing.T) {
+	t.Run("Should return 409 when notification is no longer pending", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"message":"Hello again"}`))
+		request.SetPathValue("id", "1")
+
+		message := "Hello again"
+
+		repository := mocks.NewRepository(t)
+		repository.On("UpdateNotificationByID", int64(1), &notifications.UpdateNotification{Message: &message}).Return(false, notifications.ErrNotificationNotPending)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusConflict
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errNotEditable))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestNotFoundOnUpdate(t *testing.T) {
+	t.Run("Should return 404 when notification not found", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"message":"Hello again"}`))
+		request.SetPathValue("id", "1")
+
+		message := "Hello again"
+
+		repository := mocks.NewRepository(t)
+		repository.On("UpdateNotificationByID", int64(1), &notifications.UpdateNotification{Message: &message}).Return(false, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusNotFound
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errNotFound))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+
+	t.Run("Should return 404 when changing the recipient of a notification not found", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"recipient":"+5511988888888"}`))
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(nil, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusNotFound
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(errNotFound))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestRecipientOnUpdate(t *testing.T) {
+	notification := notifications.Notification{
+		Id:        1,
+		Type:      "sms",
+		Recipient: "+5511999999999",
+		Message:   "Hello",
+		Status:    notifications.StatusScheduled,
+	}
+
+	t.Run("Should normalize the recipient according to the notification type", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"recipient":"+55 (11) 98888-8888"}`))
+		request.SetPathValue("id", "1")
+
+		recipient := "+5511988888888"
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+		repository.On("UpdateNotificationByID", int64(1), &notifications.UpdateNotification{Recipient: &recipient}).Return(true, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Should return 422 when the recipient is invalid for the notification type", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"recipient":"test@example.com"}`))
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(&httputil.UnprocessableEntityError{Errors: []string{`The field "recipient" ` + notifications.ErrInvalidPhoneNumber.Error()}})
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+	t.Run("Should return 422 when the recipient is suppressed for the notification type", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"recipient":"+55 (11) 98888-8888"}`))
+		request.SetPathValue("id", "1")
+
+		key := suppressions.Key{Type: "sms", Recipient: "+5511988888888"}
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+
+		suppressionRepository := suppressionMocks.NewRepository(t)
+		suppressionRepository.On("FindSuppressed", []suppressions.Key{key}).Return(map[suppressions.Key]string{key: "complaint"}, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), suppressionRepository, contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(&httputil.UnprocessableEntityError{
+			Errors: []string{`The field "recipient" is suppressed for "sms" notifications (complaint)`},
+		})
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestSendWindowOnUpdate(t *testing.T) {
+	timezone := "America/Sao_Paulo"
+	contactId := int64(3)
+
+	t.Run("Should delay the new schedule to the send window of the notification", func(t *testing.T) {
+		notification := notifications.Notification{
+			Id:         1,
+			Type:       "sms",
+			Recipient:  "+5511999999999",
+			Message:    "Hello",
+			Status:     notifications.StatusScheduled,
+			SendWindow: &notifications.SendWindow{Start: "08:00", End: "21:00"},
+			Timezone:   &timezone,
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"scheduled_at":"2030-01-07T02:00:00Z"}`))
+		request.SetPathValue("id", "1")
+
+		scheduledAt := time.Date(2030, time.January, 7, 2, 0, 0, 0, time.UTC)
+		deliverNotBefore := time.Date(2030, time.January, 7, 11, 0, 0, 0, time.UTC)
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+		repository.On("UpdateNotificationByID", int64(1), mock.MatchedBy(func(updateNotification *notifications.UpdateNotification) bool {
+			return updateNotification.ScheduledAt.Equal(scheduledAt) && updateNotification.DeliverNotBefore.Equal(deliverNotBefore)
+		})).Return(true, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Should return 422 when the send window is inside the quiet hours of the notification", func(t *testing.T) {
+		notification := notifications.Notification{
+			Id:         1,
+			Type:       "sms",
+			Recipient:  "+5511999999999",
+			Message:    "Hello",
+			Status:     notifications.StatusScheduled,
+			ContactId:  &contactId,
+			SendWindow: &notifications.SendWindow{Start: "23:00", End: "23:30"},
+			Timezone:   &timezone,
+			QuietHours: &notifications.QuietHours{Start: "22:00", End: "08:00", Timezone: timezone},
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"scheduled_at":"2030-01-07T02:00:00Z"}`))
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(&httputil.UnprocessableEntityError{Errors: []string{`The field "send_window" never opens outside the quiet hours of the contact`}})
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestExpirationOnUpdate(t *testing.T) {
+	t.Run("Should return 422 when rescheduling after the expiry of the notification", func(t *testing.T) {
+		expiresAt := time.Date(2030, time.January, 7, 12, 10, 0, 0, time.UTC)
+		notification := notifications.Notification{
+			Id:        1,
+			Type:      "sms",
+			Recipient: "+5511999999999",
+			Message:   "Your code is 123456",
+			Status:    notifications.StatusScheduled,
+			ExpiresAt: &expiresAt,
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"scheduled_at":"2030-01-07T13:00:00Z"}`))
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(&httputil.UnprocessableEntityError{Errors: []string{`The field "expires_at" must be after "scheduled_at"`}})
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestFrequencyCapOnUpdate(t *testing.T) {
+	scheduledAt := time.Date(2030, time.January, 7, 12, 0, 0, 0, time.UTC)
+	deliveries := []time.Time{scheduledAt.Add(-4 * time.Hour), scheduledAt.Add(-2 * time.Hour)}
+	nextAllowed := scheduledAt.Add(20 * time.Hour)
+	notification := notifications.Notification{
+		Id:        1,
+		Type:      "sms",
+		Recipient: "+5511999999999",
+		Message:   "Hello",
+		Status:    notifications.StatusScheduled,
+	}
+
+	capped := func(mode string) notifications.FrequencyCaps {
+		return notifications.FrequencyCaps{"sms": {Max: 2, Window: 24 * time.Hour, Mode: mode}}
+	}
+
+	t.Run("Should return 429 when the new schedule is over the cap", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"scheduled_at":"2030-01-07T12:00:00Z"}`))
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+		repository.On("FindRecipientDeliveries", "sms", "+5511999999999", scheduledAt.Add(-24*time.Hour), int64(1)).Return(deliveries, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapReject))).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusTooManyRequests, response.Code)
+		assert.Contains(t, response.Body.String(), "the next allowed time is 2030-01-08T08:00:00Z")
+	})
+
+	t.Run("Should defer the new schedule to the next allowed time", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"scheduled_at":"2030-01-07T12:00:00Z"}`))
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+		repository.On("FindRecipientDeliveries", "sms", "+5511999999999", scheduledAt.Add(-24*time.Hour), int64(1)).Return(deliveries, nil)
+		repository.On("UpdateNotificationByID", int64(1), mock.MatchedBy(func(updateNotification *notifications.UpdateNotification) bool {
+			return updateNotification.ScheduledAt.Equal(scheduledAt) && updateNotification.DeliverNotBefore.Equal(nextAllowed)
+		})).Return(true, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapDefer))).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Should count the deliveries of the new recipient", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PATCH", "/notifications/1", strings.NewReader(`{"recipient":"+5511888888888","scheduled_at":"2030-01-07T12:00:00Z"}`))
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindNotificationByID", int64(1)).Return(&notification, nil)
+		repository.On("FindRecipientDeliveries", "sms", "+5511888888888", scheduledAt.Add(-24*time.Hour), int64(1)).Return(deliveries[:1], nil)
+		repository.On("UpdateNotificationByID", int64(1), mock.MatchedBy(func(updateNotification *notifications.UpdateNotification) bool {
+			return updateNotification.ScheduledAt.Equal(scheduledAt) && updateNotification.DeliverNotBefore == nil
+		})).Return(true, nil)
+
+		NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), capped(notifications.FrequencyCapReject))).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+}
+
+func TestInvalidRequestOnUpdate(t *testing.T) {
+	pastScheduledAt := time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+	testCases := []struct {
+		name                 string
+		id                   string
+		body                 io.Reader
+		expectedStatusCode   int
+		expectedBodyContains string
+	}{
+		{"Should return 400 when id is invalid", "invalid-id", strings.NewReader(`{"message":"Hello"}`), http.StatusBadRequest, errInvalidOrMissingId.Error()},
+		{"Should return 400 when body is invalid", "1", strings.NewReader(`invalid`), http.StatusBadRequest, errInvalidBody.Error()},
+		{"Should return 422 when no field is informed", "1", strings.NewReader(`{}`), http.StatusUnprocessableEntity, `\"scheduled_at\"`},
+		{"Should return 422 when message is empty", "1", strings.NewReader(`{"message":""}`), http.StatusUnprocessableEntity, `\"message\"`},
+		{"Should return 422 when recipient is too short", "1", strings.NewReader(`{"recipient":"ab"}`), http.StatusUnprocessableEntity, `\"recipient\"`},
+		{"Should return 422 when scheduled_at is in the past", "1", strings.NewReader(`{"scheduled_at":"` + pastScheduledAt + `"}`), http.StatusUnprocessableEntity, `\"scheduled_at\"`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("PATCH", "/notifications/"+tc.id, tc.body)
+			request.SetPathValue("id", tc.id)
+
+			repository := mocks.NewRepository(t)
+
+			NewUpdateHandler(repository, prepare.NewPreparer(repository, templateMocks.NewRepository(t), unsuppressed(t), contactMocks.NewRepository(t), nil)).
+				Handler(response, request)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package notifications
+
+import "errors"
+
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+
+// IdempotencyKey identifies a create request retried by a client. RequestHash is
+// compared on replays to detect the same key being sent with another payload.
+type IdempotencyKey struct {
+	ClientId    string
+	Key         string
+	RequestHash string
+}
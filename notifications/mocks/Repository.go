@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	time "time"
+
 	notifications "github.com/Tagliatti/magalu-challenge/notifications"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -20,6 +22,122 @@ func (_m *Repository) EXPECT() *Repository_Expecter {
 	return &Repository_Expecter{mock: &_m.Mock}
 }
 
+// CancelNotificationByID provides a mock function with given fields: id, reason
+func (_m *Repository) CancelNotificationByID(id int64, reason string) (bool, error) {
+	ret := _m.Called(id, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelNotificationByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string) (bool, error)); ok {
+		return rf(id, reason)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) bool); ok {
+		r0 = rf(id, reason)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(id, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_CancelNotificationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelNotificationByID'
+type Repository_CancelNotificationByID_Call struct {
+	*mock.Call
+}
+
+// CancelNotificationByID is a helper method to define mock.On call
+//   - id int64
+//   - reason string
+func (_e *Repository_Expecter) CancelNotificationByID(id interface{}, reason interface{}) *Repository_CancelNotificationByID_Call {
+	return &Repository_CancelNotificationByID_Call{Call: _e.mock.On("CancelNotificationByID", id, reason)}
+}
+
+func (_c *Repository_CancelNotificationByID_Call) Run(run func(id int64, reason string)) *Repository_CancelNotificationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_CancelNotificationByID_Call) Return(_a0 bool, _a1 error) *Repository_CancelNotificationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_CancelNotificationByID_Call) RunAndReturn(run func(int64, string) (bool, error)) *Repository_CancelNotificationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimDueNotifications provides a mock function with given fields: limit, lease
+func (_m *Repository) ClaimDueNotifications(limit int, lease time.Duration) ([]*notifications.Notification, error) {
+	ret := _m.Called(limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueNotifications")
+	}
+
+	var r0 []*notifications.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, time.Duration) ([]*notifications.Notification, error)); ok {
+		return rf(limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(int, time.Duration) []*notifications.Notification); ok {
+		r0 = rf(limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*notifications.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, time.Duration) error); ok {
+		r1 = rf(limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ClaimDueNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueNotifications'
+type Repository_ClaimDueNotifications_Call struct {
+	*mock.Call
+}
+
+// ClaimDueNotifications is a helper method to define mock.On call
+//   - limit int
+//   - lease time.Duration
+func (_e *Repository_Expecter) ClaimDueNotifications(limit interface{}, lease interface{}) *Repository_ClaimDueNotifications_Call {
+	return &Repository_ClaimDueNotifications_Call{Call: _e.mock.On("ClaimDueNotifications", limit, lease)}
+}
+
+func (_c *Repository_ClaimDueNotifications_Call) Run(run func(limit int, lease time.Duration)) *Repository_ClaimDueNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Repository_ClaimDueNotifications_Call) Return(_a0 []*notifications.Notification, _a1 error) *Repository_ClaimDueNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ClaimDueNotifications_Call) RunAndReturn(run func(int, time.Duration) ([]*notifications.Notification, error)) *Repository_ClaimDueNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateNotification provides a mock function with given fields: createNotification
 func (_m *Repository) CreateNotification(createNotification *notifications.CreateNotification) (int64, error) {
 	ret := _m.Called(createNotification)
@@ -76,6 +194,128 @@ func (_c *Repository_CreateNotification_Call) RunAndReturn(run func(*notificatio
 	return _c
 }
 
+// CreateNotificationWithIdempotencyKey provides a mock function with given fields: idempotencyKey, createNotification
+func (_m *Repository) CreateNotificationWithIdempotencyKey(idempotencyKey *notifications.IdempotencyKey, createNotification *notifications.CreateNotification) (int64, bool, error) {
+	ret := _m.Called(idempotencyKey, createNotification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotificationWithIdempotencyKey")
+	}
+
+	var r0 int64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*notifications.IdempotencyKey, *notifications.CreateNotification) (int64, bool, error)); ok {
+		return rf(idempotencyKey, createNotification)
+	}
+	if rf, ok := ret.Get(0).(func(*notifications.IdempotencyKey, *notifications.CreateNotification) int64); ok {
+		r0 = rf(idempotencyKey, createNotification)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*notifications.IdempotencyKey, *notifications.CreateNotification) bool); ok {
+		r1 = rf(idempotencyKey, createNotification)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(*notifications.IdempotencyKey, *notifications.CreateNotification) error); ok {
+		r2 = rf(idempotencyKey, createNotification)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Repository_CreateNotificationWithIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotificationWithIdempotencyKey'
+type Repository_CreateNotificationWithIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// CreateNotificationWithIdempotencyKey is a helper method to define mock.On call
+//   - idempotencyKey *notifications.IdempotencyKey
+//   - createNotification *notifications.CreateNotification
+func (_e *Repository_Expecter) CreateNotificationWithIdempotencyKey(idempotencyKey interface{}, createNotification interface{}) *Repository_CreateNotificationWithIdempotencyKey_Call {
+	return &Repository_CreateNotificationWithIdempotencyKey_Call{Call: _e.mock.On("CreateNotificationWithIdempotencyKey", idempotencyKey, createNotification)}
+}
+
+func (_c *Repository_CreateNotificationWithIdempotencyKey_Call) Run(run func(idempotencyKey *notifications.IdempotencyKey, createNotification *notifications.CreateNotification)) *Repository_CreateNotificationWithIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*notifications.IdempotencyKey), args[1].(*notifications.CreateNotification))
+	})
+	return _c
+}
+
+func (_c *Repository_CreateNotificationWithIdempotencyKey_Call) Return(_a0 int64, _a1 bool, _a2 error) *Repository_CreateNotificationWithIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Repository_CreateNotificationWithIdempotencyKey_Call) RunAndReturn(run func(*notifications.IdempotencyKey, *notifications.CreateNotification) (int64, bool, error)) *Repository_CreateNotificationWithIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateNotifications provides a mock function with given fields: createNotifications
+func (_m *Repository) CreateNotifications(createNotifications []*notifications.CreateNotification) ([]int64, error) {
+	ret := _m.Called(createNotifications)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotifications")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]*notifications.CreateNotification) ([]int64, error)); ok {
+		return rf(createNotifications)
+	}
+	if rf, ok := ret.Get(0).(func([]*notifications.CreateNotification) []int64); ok {
+		r0 = rf(createNotifications)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]*notifications.CreateNotification) error); ok {
+		r1 = rf(createNotifications)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_CreateNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotifications'
+type Repository_CreateNotifications_Call struct {
+	*mock.Call
+}
+
+// CreateNotifications is a helper method to define mock.On call
+//   - createNotifications []*notifications.CreateNotification
+func (_e *Repository_Expecter) CreateNotifications(createNotifications interface{}) *Repository_CreateNotifications_Call {
+	return &Repository_CreateNotifications_Call{Call: _e.mock.On("CreateNotifications", createNotifications)}
+}
+
+func (_c *Repository_CreateNotifications_Call) Run(run func(createNotifications []*notifications.CreateNotification)) *Repository_CreateNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]*notifications.CreateNotification))
+	})
+	return _c
+}
+
+func (_c *Repository_CreateNotifications_Call) Return(_a0 []int64, _a1 error) *Repository_CreateNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_CreateNotifications_Call) RunAndReturn(run func([]*notifications.CreateNotification) ([]int64, error)) *Repository_CreateNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteNotificationByID provides a mock function with given fields: id
 func (_m *Repository) DeleteNotificationByID(id int64) (bool, error) {
 	ret := _m.Called(id)
@@ -132,6 +372,118 @@ func (_c *Repository_DeleteNotificationByID_Call) RunAndReturn(run func(int64) (
 	return _c
 }
 
+// ExpireNotifications provides a mock function with given fields: lease
+func (_m *Repository) ExpireNotifications(lease time.Duration) (int64, error) {
+	ret := _m.Called(lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireNotifications")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Duration) (int64, error)); ok {
+		return rf(lease)
+	}
+	if rf, ok := ret.Get(0).(func(time.Duration) int64); ok {
+		r0 = rf(lease)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Duration) error); ok {
+		r1 = rf(lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ExpireNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireNotifications'
+type Repository_ExpireNotifications_Call struct {
+	*mock.Call
+}
+
+// ExpireNotifications is a helper method to define mock.On call
+//   - lease time.Duration
+func (_e *Repository_Expecter) ExpireNotifications(lease interface{}) *Repository_ExpireNotifications_Call {
+	return &Repository_ExpireNotifications_Call{Call: _e.mock.On("ExpireNotifications", lease)}
+}
+
+func (_c *Repository_ExpireNotifications_Call) Run(run func(lease time.Duration)) *Repository_ExpireNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Repository_ExpireNotifications_Call) Return(_a0 int64, _a1 error) *Repository_ExpireNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ExpireNotifications_Call) RunAndReturn(run func(time.Duration) (int64, error)) *Repository_ExpireNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindIdempotentNotification provides a mock function with given fields: idempotencyKey
+func (_m *Repository) FindIdempotentNotification(idempotencyKey *notifications.IdempotencyKey) (int64, error) {
+	ret := _m.Called(idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindIdempotentNotification")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*notifications.IdempotencyKey) (int64, error)); ok {
+		return rf(idempotencyKey)
+	}
+	if rf, ok := ret.Get(0).(func(*notifications.IdempotencyKey) int64); ok {
+		r0 = rf(idempotencyKey)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*notifications.IdempotencyKey) error); ok {
+		r1 = rf(idempotencyKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindIdempotentNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindIdempotentNotification'
+type Repository_FindIdempotentNotification_Call struct {
+	*mock.Call
+}
+
+// FindIdempotentNotification is a helper method to define mock.On call
+//   - idempotencyKey *notifications.IdempotencyKey
+func (_e *Repository_Expecter) FindIdempotentNotification(idempotencyKey interface{}) *Repository_FindIdempotentNotification_Call {
+	return &Repository_FindIdempotentNotification_Call{Call: _e.mock.On("FindIdempotentNotification", idempotencyKey)}
+}
+
+func (_c *Repository_FindIdempotentNotification_Call) Run(run func(idempotencyKey *notifications.IdempotencyKey)) *Repository_FindIdempotentNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*notifications.IdempotencyKey))
+	})
+	return _c
+}
+
+func (_c *Repository_FindIdempotentNotification_Call) Return(_a0 int64, _a1 error) *Repository_FindIdempotentNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindIdempotentNotification_Call) RunAndReturn(run func(*notifications.IdempotencyKey) (int64, error)) *Repository_FindIdempotentNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindNotificationByID provides a mock function with given fields: id
 func (_m *Repository) FindNotificationByID(id int64) (*notifications.Notification, error) {
 	ret := _m.Called(id)
@@ -190,24 +542,24 @@ func (_c *Repository_FindNotificationByID_Call) RunAndReturn(run func(int64) (*n
 	return _c
 }
 
-// FindNotificationStatusByID provides a mock function with given fields: id
-func (_m *Repository) FindNotificationStatusByID(id int64) (*notifications.NotificationStatus, error) {
+// FindNotificationEventsByID provides a mock function with given fields: id
+func (_m *Repository) FindNotificationEventsByID(id int64) ([]*notifications.Event, error) {
 	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FindNotificationStatusByID")
+		panic("no return value specified for FindNotificationEventsByID")
 	}
 
-	var r0 *notifications.NotificationStatus
+	var r0 []*notifications.Event
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (*notifications.NotificationStatus, error)); ok {
+	if rf, ok := ret.Get(0).(func(int64) ([]*notifications.Event, error)); ok {
 		return rf(id)
 	}
-	if rf, ok := ret.Get(0).(func(int64) *notifications.NotificationStatus); ok {
+	if rf, ok := ret.Get(0).(func(int64) []*notifications.Event); ok {
 		r0 = rf(id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*notifications.NotificationStatus)
+			r0 = ret.Get(0).([]*notifications.Event)
 		}
 	}
 
@@ -220,51 +572,53 @@ func (_m *Repository) FindNotificationStatusByID(id int64) (*notifications.Notif
 	return r0, r1
 }
 
-// Repository_FindNotificationStatusByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindNotificationStatusByID'
-type Repository_FindNotificationStatusByID_Call struct {
+// Repository_FindNotificationEventsByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindNotificationEventsByID'
+type Repository_FindNotificationEventsByID_Call struct {
 	*mock.Call
 }
 
-// FindNotificationStatusByID is a helper method to define mock.On call
+// FindNotificationEventsByID is a helper method to define mock.On call
 //   - id int64
-func (_e *Repository_Expecter) FindNotificationStatusByID(id interface{}) *Repository_FindNotificationStatusByID_Call {
-	return &Repository_FindNotificationStatusByID_Call{Call: _e.mock.On("FindNotificationStatusByID", id)}
+func (_e *Repository_Expecter) FindNotificationEventsByID(id interface{}) *Repository_FindNotificationEventsByID_Call {
+	return &Repository_FindNotificationEventsByID_Call{Call: _e.mock.On("FindNotificationEventsByID", id)}
 }
 
-func (_c *Repository_FindNotificationStatusByID_Call) Run(run func(id int64)) *Repository_FindNotificationStatusByID_Call {
+func (_c *Repository_FindNotificationEventsByID_Call) Run(run func(id int64)) *Repository_FindNotificationEventsByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(int64))
 	})
 	return _c
 }
 
-func (_c *Repository_FindNotificationStatusByID_Call) Return(_a0 *notifications.NotificationStatus, _a1 error) *Repository_FindNotificationStatusByID_Call {
+func (_c *Repository_FindNotificationEventsByID_Call) Return(_a0 []*notifications.Event, _a1 error) *Repository_FindNotificationEventsByID_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_FindNotificationStatusByID_Call) RunAndReturn(run func(int64) (*notifications.NotificationStatus, error)) *Repository_FindNotificationStatusByID_Call {
+func (_c *Repository_FindNotificationEventsByID_Call) RunAndReturn(run func(int64) ([]*notifications.Event, error)) *Repository_FindNotificationEventsByID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateNotificationAsSent provides a mock function with given fields: id
-func (_m *Repository) UpdateNotificationAsSent(id int64) (bool, error) {
+// FindNotificationStatusByID provides a mock function with given fields: id
+func (_m *Repository) FindNotificationStatusByID(id int64) (*notifications.NotificationStatus, error) {
 	ret := _m.Called(id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateNotificationAsSent")
+		panic("no return value specified for FindNotificationStatusByID")
 	}
 
-	var r0 bool
+	var r0 *notifications.NotificationStatus
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+	if rf, ok := ret.Get(0).(func(int64) (*notifications.NotificationStatus, error)); ok {
 		return rf(id)
 	}
-	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+	if rf, ok := ret.Get(0).(func(int64) *notifications.NotificationStatus); ok {
 		r0 = rf(id)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*notifications.NotificationStatus)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(int64) error); ok {
@@ -276,30 +630,665 @@ func (_m *Repository) UpdateNotificationAsSent(id int64) (bool, error) {
 	return r0, r1
 }
 
-// Repository_UpdateNotificationAsSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationAsSent'
-type Repository_UpdateNotificationAsSent_Call struct {
+// Repository_FindNotificationStatusByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindNotificationStatusByID'
+type Repository_FindNotificationStatusByID_Call struct {
 	*mock.Call
 }
 
-// UpdateNotificationAsSent is a helper method to define mock.On call
+// FindNotificationStatusByID is a helper method to define mock.On call
 //   - id int64
-func (_e *Repository_Expecter) UpdateNotificationAsSent(id interface{}) *Repository_UpdateNotificationAsSent_Call {
-	return &Repository_UpdateNotificationAsSent_Call{Call: _e.mock.On("UpdateNotificationAsSent", id)}
+func (_e *Repository_Expecter) FindNotificationStatusByID(id interface{}) *Repository_FindNotificationStatusByID_Call {
+	return &Repository_FindNotificationStatusByID_Call{Call: _e.mock.On("FindNotificationStatusByID", id)}
 }
 
-func (_c *Repository_UpdateNotificationAsSent_Call) Run(run func(id int64)) *Repository_UpdateNotificationAsSent_Call {
+func (_c *Repository_FindNotificationStatusByID_Call) Run(run func(id int64)) *Repository_FindNotificationStatusByID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(int64))
 	})
 	return _c
 }
 
+func (_c *Repository_FindNotificationStatusByID_Call) Return(_a0 *notifications.NotificationStatus, _a1 error) *Repository_FindNotificationStatusByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindNotificationStatusByID_Call) RunAndReturn(run func(int64) (*notifications.NotificationStatus, error)) *Repository_FindNotificationStatusByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRecipientDeliveries provides a mock function with given fields: notificationType, recipient, since, excludeId
+func (_m *Repository) FindRecipientDeliveries(notificationType string, recipient string, since time.Time, excludeId int64) ([]time.Time, error) {
+	ret := _m.Called(notificationType, recipient, since, excludeId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecipientDeliveries")
+	}
+
+	var r0 []time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, time.Time, int64) ([]time.Time, error)); ok {
+		return rf(notificationType, recipient, since, excludeId)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, time.Time, int64) []time.Time); ok {
+		r0 = rf(notificationType, recipient, since, excludeId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, time.Time, int64) error); ok {
+		r1 = rf(notificationType, recipient, since, excludeId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindRecipientDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRecipientDeliveries'
+type Repository_FindRecipientDeliveries_Call struct {
+	*mock.Call
+}
+
+// FindRecipientDeliveries is a helper method to define mock.On call
+//   - notificationType string
+//   - recipient string
+//   - since time.Time
+//   - excludeId int64
+func (_e *Repository_Expecter) FindRecipientDeliveries(notificationType interface{}, recipient interface{}, since interface{}, excludeId interface{}) *Repository_FindRecipientDeliveries_Call {
+	return &Repository_FindRecipientDeliveries_Call{Call: _e.mock.On("FindRecipientDeliveries", notificationType, recipient, since, excludeId)}
+}
+
+func (_c *Repository_FindRecipientDeliveries_Call) Run(run func(notificationType string, recipient string, since time.Time, excludeId int64)) *Repository_FindRecipientDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(time.Time), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_FindRecipientDeliveries_Call) Return(_a0 []time.Time, _a1 error) *Repository_FindRecipientDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindRecipientDeliveries_Call) RunAndReturn(run func(string, string, time.Time, int64) ([]time.Time, error)) *Repository_FindRecipientDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRecipientsDeliveries provides a mock function with given fields: since
+func (_m *Repository) FindRecipientsDeliveries(since map[notifications.RecipientKey]time.Time) (map[notifications.RecipientKey][]time.Time, error) {
+	ret := _m.Called(since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecipientsDeliveries")
+	}
+
+	var r0 map[notifications.RecipientKey][]time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[notifications.RecipientKey]time.Time) (map[notifications.RecipientKey][]time.Time, error)); ok {
+		return rf(since)
+	}
+	if rf, ok := ret.Get(0).(func(map[notifications.RecipientKey]time.Time) map[notifications.RecipientKey][]time.Time); ok {
+		r0 = rf(since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[notifications.RecipientKey][]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[notifications.RecipientKey]time.Time) error); ok {
+		r1 = rf(since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindRecipientsDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRecipientsDeliveries'
+type Repository_FindRecipientsDeliveries_Call struct {
+	*mock.Call
+}
+
+// FindRecipientsDeliveries is a helper method to define mock.On call
+//   - since map[notifications.RecipientKey]time.Time
+func (_e *Repository_Expecter) FindRecipientsDeliveries(since interface{}) *Repository_FindRecipientsDeliveries_Call {
+	return &Repository_FindRecipientsDeliveries_Call{Call: _e.mock.On("FindRecipientsDeliveries", since)}
+}
+
+func (_c *Repository_FindRecipientsDeliveries_Call) Run(run func(since map[notifications.RecipientKey]time.Time)) *Repository_FindRecipientsDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(map[notifications.RecipientKey]time.Time))
+	})
+	return _c
+}
+
+func (_c *Repository_FindRecipientsDeliveries_Call) Return(_a0 map[notifications.RecipientKey][]time.Time, _a1 error) *Repository_FindRecipientsDeliveries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindRecipientsDeliveries_Call) RunAndReturn(run func(map[notifications.RecipientKey]time.Time) (map[notifications.RecipientKey][]time.Time, error)) *Repository_FindRecipientsDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNotifications provides a mock function with given fields: filter
+func (_m *Repository) ListNotifications(filter *notifications.ListNotificationsFilter) (*notifications.NotificationPage, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNotifications")
+	}
+
+	var r0 *notifications.NotificationPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*notifications.ListNotificationsFilter) (*notifications.NotificationPage, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*notifications.ListNotificationsFilter) *notifications.NotificationPage); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*notifications.NotificationPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*notifications.ListNotificationsFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNotifications'
+type Repository_ListNotifications_Call struct {
+	*mock.Call
+}
+
+// ListNotifications is a helper method to define mock.On call
+//   - filter *notifications.ListNotificationsFilter
+func (_e *Repository_Expecter) ListNotifications(filter interface{}) *Repository_ListNotifications_Call {
+	return &Repository_ListNotifications_Call{Call: _e.mock.On("ListNotifications", filter)}
+}
+
+func (_c *Repository_ListNotifications_Call) Run(run func(filter *notifications.ListNotificationsFilter)) *Repository_ListNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*notifications.ListNotificationsFilter))
+	})
+	return _c
+}
+
+func (_c *Repository_ListNotifications_Call) Return(_a0 *notifications.NotificationPage, _a1 error) *Repository_ListNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListNotifications_Call) RunAndReturn(run func(*notifications.ListNotificationsFilter) (*notifications.NotificationPage, error)) *Repository_ListNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseNotification provides a mock function with given fields: id
+func (_m *Repository) ReleaseNotification(id int64) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseNotification")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ReleaseNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseNotification'
+type Repository_ReleaseNotification_Call struct {
+	*mock.Call
+}
+
+// ReleaseNotification is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) ReleaseNotification(id interface{}) *Repository_ReleaseNotification_Call {
+	return &Repository_ReleaseNotification_Call{Call: _e.mock.On("ReleaseNotification", id)}
+}
+
+func (_c *Repository_ReleaseNotification_Call) Run(run func(id int64)) *Repository_ReleaseNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_ReleaseNotification_Call) Return(_a0 bool, _a1 error) *Repository_ReleaseNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ReleaseNotification_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_ReleaseNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryNotificationByID provides a mock function with given fields: id, nextAttemptAt
+func (_m *Repository) RetryNotificationByID(id int64, nextAttemptAt time.Time) (bool, error) {
+	ret := _m.Called(id, nextAttemptAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryNotificationByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, time.Time) (bool, error)); ok {
+		return rf(id, nextAttemptAt)
+	}
+	if rf, ok := ret.Get(0).(func(int64, time.Time) bool); ok {
+		r0 = rf(id, nextAttemptAt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, time.Time) error); ok {
+		r1 = rf(id, nextAttemptAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_RetryNotificationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryNotificationByID'
+type Repository_RetryNotificationByID_Call struct {
+	*mock.Call
+}
+
+// RetryNotificationByID is a helper method to define mock.On call
+//   - id int64
+//   - nextAttemptAt time.Time
+func (_e *Repository_Expecter) RetryNotificationByID(id interface{}, nextAttemptAt interface{}) *Repository_RetryNotificationByID_Call {
+	return &Repository_RetryNotificationByID_Call{Call: _e.mock.On("RetryNotificationByID", id, nextAttemptAt)}
+}
+
+func (_c *Repository_RetryNotificationByID_Call) Run(run func(id int64, nextAttemptAt time.Time)) *Repository_RetryNotificationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Repository_RetryNotificationByID_Call) Return(_a0 bool, _a1 error) *Repository_RetryNotificationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_RetryNotificationByID_Call) RunAndReturn(run func(int64, time.Time) (bool, error)) *Repository_RetryNotificationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotificationAsExpired provides a mock function with given fields: id
+func (_m *Repository) UpdateNotificationAsExpired(id int64) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationAsExpired")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UpdateNotificationAsExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationAsExpired'
+type Repository_UpdateNotificationAsExpired_Call struct {
+	*mock.Call
+}
+
+// UpdateNotificationAsExpired is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) UpdateNotificationAsExpired(id interface{}) *Repository_UpdateNotificationAsExpired_Call {
+	return &Repository_UpdateNotificationAsExpired_Call{Call: _e.mock.On("UpdateNotificationAsExpired", id)}
+}
+
+func (_c *Repository_UpdateNotificationAsExpired_Call) Run(run func(id int64)) *Repository_UpdateNotificationAsExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateNotificationAsExpired_Call) Return(_a0 bool, _a1 error) *Repository_UpdateNotificationAsExpired_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_UpdateNotificationAsExpired_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_UpdateNotificationAsExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotificationAsFailed provides a mock function with given fields: id, failure
+func (_m *Repository) UpdateNotificationAsFailed(id int64, failure string) (bool, error) {
+	ret := _m.Called(id, failure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationAsFailed")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string) (bool, error)); ok {
+		return rf(id, failure)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) bool); ok {
+		r0 = rf(id, failure)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(id, failure)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UpdateNotificationAsFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationAsFailed'
+type Repository_UpdateNotificationAsFailed_Call struct {
+	*mock.Call
+}
+
+// UpdateNotificationAsFailed is a helper method to define mock.On call
+//   - id int64
+//   - failure string
+func (_e *Repository_Expecter) UpdateNotificationAsFailed(id interface{}, failure interface{}) *Repository_UpdateNotificationAsFailed_Call {
+	return &Repository_UpdateNotificationAsFailed_Call{Call: _e.mock.On("UpdateNotificationAsFailed", id, failure)}
+}
+
+func (_c *Repository_UpdateNotificationAsFailed_Call) Run(run func(id int64, failure string)) *Repository_UpdateNotificationAsFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateNotificationAsFailed_Call) Return(_a0 bool, _a1 error) *Repository_UpdateNotificationAsFailed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_UpdateNotificationAsFailed_Call) RunAndReturn(run func(int64, string) (bool, error)) *Repository_UpdateNotificationAsFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotificationAsSent provides a mock function with given fields: id, channel
+func (_m *Repository) UpdateNotificationAsSent(id int64, channel string) (bool, error) {
+	ret := _m.Called(id, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationAsSent")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string) (bool, error)); ok {
+		return rf(id, channel)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string) bool); ok {
+		r0 = rf(id, channel)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(id, channel)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UpdateNotificationAsSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationAsSent'
+type Repository_UpdateNotificationAsSent_Call struct {
+	*mock.Call
+}
+
+// UpdateNotificationAsSent is a helper method to define mock.On call
+//   - id int64
+//   - channel string
+func (_e *Repository_Expecter) UpdateNotificationAsSent(id interface{}, channel interface{}) *Repository_UpdateNotificationAsSent_Call {
+	return &Repository_UpdateNotificationAsSent_Call{Call: _e.mock.On("UpdateNotificationAsSent", id, channel)}
+}
+
+func (_c *Repository_UpdateNotificationAsSent_Call) Run(run func(id int64, channel string)) *Repository_UpdateNotificationAsSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(string))
+	})
+	return _c
+}
+
 func (_c *Repository_UpdateNotificationAsSent_Call) Return(_a0 bool, _a1 error) *Repository_UpdateNotificationAsSent_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Repository_UpdateNotificationAsSent_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_UpdateNotificationAsSent_Call {
+func (_c *Repository_UpdateNotificationAsSent_Call) RunAndReturn(run func(int64, string) (bool, error)) *Repository_UpdateNotificationAsSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotificationByID provides a mock function with given fields: id, updateNotification
+func (_m *Repository) UpdateNotificationByID(id int64, updateNotification *notifications.UpdateNotification) (bool, error) {
+	ret := _m.Called(id, updateNotification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, *notifications.UpdateNotification) (bool, error)); ok {
+		return rf(id, updateNotification)
+	}
+	if rf, ok := ret.Get(0).(func(int64, *notifications.UpdateNotification) bool); ok {
+		r0 = rf(id, updateNotification)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, *notifications.UpdateNotification) error); ok {
+		r1 = rf(id, updateNotification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UpdateNotificationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationByID'
+type Repository_UpdateNotificationByID_Call struct {
+	*mock.Call
+}
+
+// UpdateNotificationByID is a helper method to define mock.On call
+//   - id int64
+//   - updateNotification *notifications.UpdateNotification
+func (_e *Repository_Expecter) UpdateNotificationByID(id interface{}, updateNotification interface{}) *Repository_UpdateNotificationByID_Call {
+	return &Repository_UpdateNotificationByID_Call{Call: _e.mock.On("UpdateNotificationByID", id, updateNotification)}
+}
+
+func (_c *Repository_UpdateNotificationByID_Call) Run(run func(id int64, updateNotification *notifications.UpdateNotification)) *Repository_UpdateNotificationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(*notifications.UpdateNotification))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateNotificationByID_Call) Return(_a0 bool, _a1 error) *Repository_UpdateNotificationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_UpdateNotificationByID_Call) RunAndReturn(run func(int64, *notifications.UpdateNotification) (bool, error)) *Repository_UpdateNotificationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotificationForFallback provides a mock function with given fields: id, position, channel, nextAttemptAt, failure
+func (_m *Repository) UpdateNotificationForFallback(id int64, position int, channel notifications.Channel, nextAttemptAt time.Time, failure string) (bool, error) {
+	ret := _m.Called(id, position, channel, nextAttemptAt, failure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationForFallback")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int, notifications.Channel, time.Time, string) (bool, error)); ok {
+		return rf(id, position, channel, nextAttemptAt, failure)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, notifications.Channel, time.Time, string) bool); ok {
+		r0 = rf(id, position, channel, nextAttemptAt, failure)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, notifications.Channel, time.Time, string) error); ok {
+		r1 = rf(id, position, channel, nextAttemptAt, failure)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UpdateNotificationForFallback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationForFallback'
+type Repository_UpdateNotificationForFallback_Call struct {
+	*mock.Call
+}
+
+// UpdateNotificationForFallback is a helper method to define mock.On call
+//   - id int64
+//   - position int
+//   - channel notifications.Channel
+//   - nextAttemptAt time.Time
+//   - failure string
+func (_e *Repository_Expecter) UpdateNotificationForFallback(id interface{}, position interface{}, channel interface{}, nextAttemptAt interface{}, failure interface{}) *Repository_UpdateNotificationForFallback_Call {
+	return &Repository_UpdateNotificationForFallback_Call{Call: _e.mock.On("UpdateNotificationForFallback", id, position, channel, nextAttemptAt, failure)}
+}
+
+func (_c *Repository_UpdateNotificationForFallback_Call) Run(run func(id int64, position int, channel notifications.Channel, nextAttemptAt time.Time, failure string)) *Repository_UpdateNotificationForFallback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int), args[2].(notifications.Channel), args[3].(time.Time), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateNotificationForFallback_Call) Return(_a0 bool, _a1 error) *Repository_UpdateNotificationForFallback_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_UpdateNotificationForFallback_Call) RunAndReturn(run func(int64, int, notifications.Channel, time.Time, string) (bool, error)) *Repository_UpdateNotificationForFallback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNotificationForRetry provides a mock function with given fields: id, nextAttemptAt, failure
+func (_m *Repository) UpdateNotificationForRetry(id int64, nextAttemptAt time.Time, failure string) (bool, error) {
+	ret := _m.Called(id, nextAttemptAt, failure)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNotificationForRetry")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, time.Time, string) (bool, error)); ok {
+		return rf(id, nextAttemptAt, failure)
+	}
+	if rf, ok := ret.Get(0).(func(int64, time.Time, string) bool); ok {
+		r0 = rf(id, nextAttemptAt, failure)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, time.Time, string) error); ok {
+		r1 = rf(id, nextAttemptAt, failure)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UpdateNotificationForRetry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNotificationForRetry'
+type Repository_UpdateNotificationForRetry_Call struct {
+	*mock.Call
+}
+
+// UpdateNotificationForRetry is a helper method to define mock.On call
+//   - id int64
+//   - nextAttemptAt time.Time
+//   - failure string
+func (_e *Repository_Expecter) UpdateNotificationForRetry(id interface{}, nextAttemptAt interface{}, failure interface{}) *Repository_UpdateNotificationForRetry_Call {
+	return &Repository_UpdateNotificationForRetry_Call{Call: _e.mock.On("UpdateNotificationForRetry", id, nextAttemptAt, failure)}
+}
+
+func (_c *Repository_UpdateNotificationForRetry_Call) Run(run func(id int64, nextAttemptAt time.Time, failure string)) *Repository_UpdateNotificationForRetry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(time.Time), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateNotificationForRetry_Call) Return(_a0 bool, _a1 error) *Repository_UpdateNotificationForRetry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_UpdateNotificationForRetry_Call) RunAndReturn(run func(int64, time.Time, string) (bool, error)) *Repository_UpdateNotificationForRetry_Call {
 	_c.Call.Return(run)
 	return _c
 }
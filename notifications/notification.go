@@ -2,21 +2,132 @@ package notifications
 
 import "time"
 
+var Types = []string{"email", "sms", "push", "whatsapp"}
+
+// Priorities are ordered from the most to the least urgent: due notifications
+// are sent in this order, then by schedule.
+var Priorities = []string{"critical", "high", "normal", "low"}
+
+const DefaultPriority = "normal"
+
 type Notification struct {
-	Id        int64      `json:"id"`
-	CreatedAt time.Time  `json:"created_at"`
-	Type      string     `json:"type"`
-	Recipient string     `json:"recipient"`
-	Sent      bool       `json:"sent"`
-	SentAt    *time.Time `json:"sent_at"`
+	Id                      int64       `json:"id"`
+	CreatedAt               time.Time   `json:"created_at"`
+	Type                    string      `json:"type"`
+	Recipient               string      `json:"recipient"`
+	Priority                string      `json:"priority"`
+	Channels                Channels    `json:"channels"`
+	ChannelPosition         int         `json:"channel_position"`
+	SentChannel             *string     `json:"sent_channel"`
+	ContactId               *int64      `json:"contact_id"`
+	RecurringNotificationId *int64      `json:"recurring_notification_id"`
+	Message                 string      `json:"message"`
+	TemplateId              *int64      `json:"template_id"`
+	TemplateVersion         *int        `json:"template_version"`
+	Variables               Variables   `json:"variables"`
+	Locale                  *string     `json:"locale"`
+	ScheduledAt             time.Time   `json:"scheduled_at"`
+	SendWindow              *SendWindow `json:"send_window"`
+	Timezone                *string     `json:"timezone"`
+	QuietHours              *QuietHours `json:"quiet_hours"`
+	DeliverNotBefore        time.Time   `json:"deliver_not_before"`
+	ExpiresAt               *time.Time  `json:"expires_at"`
+	Status                  Status      `json:"status"`
+	SentAt                  *time.Time  `json:"sent_at"`
+	Attempts                int         `json:"attempts"`
+	NextAttemptAt           *time.Time  `json:"next_attempt_at"`
+	LastError               *string     `json:"last_error"`
 }
 
 type CreateNotification struct {
-	Type      string `json:"type"`
-	Recipient string `json:"recipient"`
+	Type                    string      `json:"type"`
+	Recipient               string      `json:"recipient"`
+	Priority                string      `json:"priority"`
+	Channels                []string    `json:"channels"`
+	Chain                   Channels    `json:"-"`
+	ChannelPosition         int         `json:"-"`
+	ContactId               *int64      `json:"contact_id" zog:"contact_id"`
+	Message                 string      `json:"message"`
+	TemplateId              *int64      `json:"template_id" zog:"template_id"`
+	TemplateVersion         int         `json:"-"`
+	Variables               Variables   `json:"variables"`
+	Locale                  string      `json:"locale"`
+	ScheduledAt             time.Time   `json:"scheduled_at" zog:"scheduled_at"`
+	SendWindow              *SendWindow `json:"send_window" zog:"send_window"`
+	Timezone                string      `json:"timezone"`
+	QuietHours              *QuietHours `json:"-"`
+	DeliverNotBefore        time.Time   `json:"-"`
+	ExpiresAt               *time.Time  `json:"expires_at" zog:"expires_at"`
+	RecurringNotificationId *int64      `json:"-"`
+}
+
+type UpdateNotification struct {
+	Recipient        *string    `json:"recipient"`
+	Message          *string    `json:"message"`
+	ScheduledAt      *time.Time `json:"scheduled_at" zog:"scheduled_at"`
+	DeliverNotBefore *time.Time `json:"-"`
 }
 
 type NotificationStatus struct {
-	Sent   bool       `json:"sent"`
-	SentAt *time.Time `json:"sent_at"`
+	Status           Status             `json:"status"`
+	Type             string             `json:"type"`
+	Recipient        string             `json:"recipient"`
+	Channels         Channels           `json:"channels"`
+	ChannelPosition  int                `json:"channel_position"`
+	SentChannel      *string            `json:"sent_channel"`
+	CreatedAt        time.Time          `json:"created_at"`
+	ScheduledAt      time.Time          `json:"scheduled_at"`
+	DeliverNotBefore time.Time          `json:"deliver_not_before"`
+	ExpiresAt        *time.Time         `json:"expires_at"`
+	QueuedAt         *time.Time         `json:"queued_at"`
+	SendingAt        *time.Time         `json:"sending_at"`
+	SentAt           *time.Time         `json:"sent_at"`
+	FailedAt         *time.Time         `json:"failed_at"`
+	CanceledAt       *time.Time         `json:"canceled_at"`
+	CancelReason     *string            `json:"cancel_reason"`
+	ExpiredAt        *time.Time         `json:"expired_at"`
+	Attempts         int                `json:"attempts"`
+	NextAttemptAt    *time.Time         `json:"next_attempt_at"`
+	LastError        *string            `json:"last_error"`
+	FrequencyCap     *FrequencyCapUsage `json:"frequency_cap"`
+}
+
+type CancelNotification struct {
+	Reason string `json:"reason"`
+}
+
+type ListNotificationsFilter struct {
+	Type                    string    `zog:"type"`
+	Recipient               string    `zog:"recipient"`
+	ContactId               int64     `zog:"contact_id"`
+	RecurringNotificationId int64     `zog:"recurring_notification_id"`
+	Status                  string    `zog:"status"`
+	CreatedFrom             time.Time `zog:"created_from"`
+	CreatedTo               time.Time `zog:"created_to"`
+	ScheduledFrom           time.Time `zog:"scheduled_from"`
+	ScheduledTo             time.Time `zog:"scheduled_to"`
+	Cursor                  int64     `zog:"cursor"`
+	Limit                   int       `zog:"limit"`
+}
+
+type NotificationPage struct {
+	Data       []*Notification `json:"data"`
+	NextCursor *int64          `json:"next_cursor"`
+}
+
+type BatchItemResult struct {
+	Index  int      `json:"index"`
+	Id     *int64   `json:"id,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+type BatchResult struct {
+	Created int                `json:"created"`
+	Failed  int                `json:"failed"`
+	Results []*BatchItemResult `json:"results"`
+}
+
+// IsExpired reports whether the notification is worthless if sent at t.
+func (n *Notification) IsExpired(t time.Time) bool {
+	return n.ExpiresAt != nil && !t.Before(*n.ExpiresAt)
 }
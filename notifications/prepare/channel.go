@@ -0,0 +1,53 @@
+package prepare
+
+import (
+	"errors"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+)
+
+var errTypeRequired = errors.New(`The field "type" or "channels" is required`)
+var errTypeOrChannels = errors.New(`Only one of the fields "type" or "channels" must be informed`)
+var errRepeatedChannel = errors.New(`The field "channels" must not repeat a type`)
+
+// channelTypes returns the types the notification may be delivered on, in order
+// of preference: the fallback chain when one was informed, otherwise its type.
+func channelTypes(createNotification *notifications.CreateNotification) []string {
+	if len(createNotification.Channels) > 0 {
+		return createNotification.Channels
+	}
+
+	if createNotification.Type != "" {
+		return []string{createNotification.Type}
+	}
+
+	return nil
+}
+
+// selectChannel points the notification at the first channel of its chain that
+// has a recipient, reporting false when there is none.
+func selectChannel(createNotification *notifications.CreateNotification) bool {
+	for position, channel := range createNotification.Chain {
+		if channel.Recipient != "" {
+			createNotification.Type = channel.Type
+			createNotification.Recipient = channel.Recipient
+			createNotification.ChannelPosition = position
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasRepeatedChannel(channels []string) bool {
+	seen := make(map[string]bool, len(channels))
+
+	for _, channel := range channels {
+		if seen[channel] {
+			return true
+		}
+
+		seen[channel] = true
+	}
+
+	return false
+}
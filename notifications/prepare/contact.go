@@ -0,0 +1,83 @@
+package prepare
+
+import (
+	"errors"
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+)
+
+var errRecipientOrContact = errors.New(`Exactly one of the fields "recipient" or "contact_id" must be informed`)
+var errContactNotFound = errors.New(`The field "contact_id" must reference an existing contact`)
+var errContactWithoutPreferredType = errors.New(`The field "type" is required, the contact has no preferred channel with a recipient`)
+var errContactWithoutChannels = errors.New(`The field "contact_id" references a contact without a recipient for any of the "channels"`)
+
+// contactResolver fills the fallback chain of notifications addressed to a
+// contact with its recipient for each type, skipping the types it has none
+// for, and delays them past the contact quiet hours. Lookups are cached so a
+// batch addressed to the same contact hits the database once.
+type contactResolver struct {
+	contactRepository contacts.Repository
+	cache             map[int64]*contacts.Contact
+}
+
+func newContactResolver(contactRepository contacts.Repository) *contactResolver {
+	return &contactResolver{
+		contactRepository: contactRepository,
+		cache:             make(map[int64]*contacts.Contact),
+	}
+}
+
+func (r *contactResolver) resolve(createNotification *notifications.CreateNotification) (*ValidationError, error) {
+	if createNotification.ContactId == nil {
+		return nil, nil
+	}
+
+	contact, ok := r.cache[*createNotification.ContactId]
+
+	if !ok {
+		var err error
+		contact, err = r.contactRepository.FindContactByID(*createNotification.ContactId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		r.cache[*createNotification.ContactId] = contact
+	}
+
+	if contact == nil {
+		return &ValidationError{Errors: []string{errContactNotFound.Error()}}, nil
+	}
+
+	types := channelTypes(createNotification)
+
+	if len(types) == 0 {
+		preferredType := contact.PreferredType()
+
+		if preferredType == "" {
+			return &ValidationError{Errors: []string{errContactWithoutPreferredType.Error()}}, nil
+		}
+
+		types = []string{preferredType}
+	}
+
+	createNotification.Chain = make(notifications.Channels, len(types))
+
+	for i, notificationType := range types {
+		createNotification.Chain[i] = notifications.Channel{Type: notificationType, Recipient: contact.Recipient(notificationType)}
+	}
+
+	if selectChannel(createNotification) {
+		createNotification.QuietHours = contact.QuietHours
+
+		return applySendWindow(createNotification), nil
+	}
+
+	if len(types) > 1 {
+		return &ValidationError{Errors: []string{errContactWithoutChannels.Error()}}, nil
+	}
+
+	return &ValidationError{
+		Errors: []string{`The field "contact_id" references a contact without a recipient for "` + types[0] + `" notifications`},
+	}, nil
+}
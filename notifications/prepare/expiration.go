@@ -0,0 +1,28 @@
+package prepare
+
+import (
+	"errors"
+	"time"
+)
+
+var errExpiresBeforeSchedule = errors.New(`The field "expires_at" must be after "scheduled_at"`)
+var errExpiresBeforeSendWindow = errors.New(`The field "expires_at" must be after the first time the notification can be sent within its send window`)
+
+// validateExpiresAt checks that a notification scheduled at scheduledAt, and
+// delayed to deliverNotBefore by its send window when not zero, can still be
+// sent before it expires.
+func validateExpiresAt(scheduledAt time.Time, deliverNotBefore time.Time, expiresAt *time.Time) *ValidationError {
+	if expiresAt == nil {
+		return nil
+	}
+
+	if !expiresAt.After(scheduledAt) {
+		return &ValidationError{Errors: []string{errExpiresBeforeSchedule.Error()}}
+	}
+
+	if !deliverNotBefore.IsZero() && !expiresAt.After(deliverNotBefore) {
+		return &ValidationError{Errors: []string{errExpiresBeforeSendWindow.Error()}}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package prepare
+
+import (
+	"fmt"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"slices"
+	"sort"
+	"time"
+)
+
+// applyFrequencyCaps checks the notifications left without errors against the
+// frequency cap of their type, in order, so the earlier ones of the same call
+// count against the later ones. Deliveries are looked up in a single query.
+func (p *Preparer) applyFrequencyCaps(createNotifications []*notifications.CreateNotification, errs map[int]*ValidationError) error {
+	since := make(map[notifications.RecipientKey]time.Time)
+
+	for i, createNotification := range createNotifications {
+		frequencyCap, ok := p.frequencyCaps.For(createNotification.Type)
+
+		if _, failed := errs[i]; failed || !ok {
+			continue
+		}
+
+		key := notifications.RecipientKey{Type: createNotification.Type, Recipient: createNotification.Recipient}
+		start := delivery(createNotification).Add(-frequencyCap.Window)
+
+		if current, ok := since[key]; !ok || start.Before(current) {
+			since[key] = start
+		}
+	}
+
+	if len(since) == 0 {
+		return nil
+	}
+
+	deliveries, err := p.notificationRepository.FindRecipientsDeliveries(since)
+
+	if err != nil {
+		return err
+	}
+
+	for i, createNotification := range createNotifications {
+		key := notifications.RecipientKey{Type: createNotification.Type, Recipient: createNotification.Recipient}
+		keyDeliveries, ok := deliveries[key]
+
+		if _, failed := errs[i]; failed || !ok {
+			continue
+		}
+
+		frequencyCap, _ := p.frequencyCaps.For(createNotification.Type)
+		validationError := applyFrequencyCap(frequencyCap, keyDeliveries, createNotification)
+
+		if validationError != nil {
+			errs[i] = validationError
+			continue
+		}
+
+		accepted := delivery(createNotification)
+		position := sort.Search(len(keyDeliveries), func(i int) bool { return keyDeliveries[i].After(accepted) })
+		deliveries[key] = slices.Insert(keyDeliveries, position, accepted)
+	}
+
+	return nil
+}
+
+// applyFrequencyCap checks the notification against the sorted deliveries of its
+// recipient, delaying it to the next allowed time when the cap defers.
+func applyFrequencyCap(frequencyCap notifications.FrequencyCap, deliveries []time.Time, createNotification *notifications.CreateNotification) *ValidationError {
+	current := delivery(createNotification)
+	next := frequencyCap.Next(deliveries, current)
+
+	if next.Equal(current) {
+		return nil
+	}
+
+	if frequencyCap.Mode != notifications.FrequencyCapDefer {
+		return newFrequencyCapError(createNotification.Type, frequencyCap, next)
+	}
+
+	// Delaying to the send window may land over the cap again.
+	for range notifications.MaxWindowShifts {
+		shifted, ok := notifications.NextSendTime(next, createNotification.SendWindow, createNotification.Timezone, createNotification.QuietHours)
+
+		if !ok {
+			break
+		}
+
+		if capped := frequencyCap.Next(deliveries, shifted); !capped.Equal(shifted) {
+			next = capped
+			continue
+		}
+
+		if createNotification.ExpiresAt != nil && !createNotification.ExpiresAt.After(shifted) {
+			break
+		}
+
+		createNotification.DeliverNotBefore = shifted
+
+		return nil
+	}
+
+	return newFrequencyCapError(createNotification.Type, frequencyCap, next)
+}
+
+// applyUpdateFrequencyCap checks the new schedule of a notification, described by
+// createNotification, against the other deliveries of its recipient.
+func (p *Preparer) applyUpdateFrequencyCap(id int64, createNotification *notifications.CreateNotification, updateNotification *notifications.UpdateNotification) (*ValidationError, error) {
+	frequencyCap, ok := p.frequencyCaps.For(createNotification.Type)
+
+	if !ok {
+		return nil, nil
+	}
+
+	since := delivery(createNotification).Add(-frequencyCap.Window)
+	deliveries, err := p.notificationRepository.FindRecipientDeliveries(createNotification.Type, createNotification.Recipient, since, id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if validationError := applyFrequencyCap(frequencyCap, deliveries, createNotification); validationError != nil {
+		return validationError, nil
+	}
+
+	if !createNotification.DeliverNotBefore.IsZero() {
+		updateNotification.DeliverNotBefore = &createNotification.DeliverNotBefore
+	}
+
+	return nil, nil
+}
+
+func newFrequencyCapError(notificationType string, frequencyCap notifications.FrequencyCap, next time.Time) *ValidationError {
+	return &ValidationError{
+		Errors: []string{fmt.Sprintf(`The recipient already has %d "%s" notifications within %s of this one, the next allowed time is %s`,
+			frequencyCap.Max, notificationType, frequencyCap.Window, next.UTC().Format(time.RFC3339))},
+		FrequencyCapped: true,
+	}
+}
+
+// delivery returns when the notification is expected to be delivered.
+func delivery(createNotification *notifications.CreateNotification) time.Time {
+	if createNotification.DeliverNotBefore.IsZero() {
+		return createNotification.ScheduledAt
+	}
+
+	return createNotification.DeliverNotBefore
+}
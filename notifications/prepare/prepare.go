@@ -0,0 +1,128 @@
+package prepare
+
+import (
+	"github.com/Tagliatti/magalu-challenge/contacts"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"strings"
+)
+
+// ValidationError lists why a notification can not be created, one message per
+// offending field. FrequencyCapped tells it was refused only for being over the
+// frequency cap of its type, so it may be created later.
+type ValidationError struct {
+	Errors          []string
+	FrequencyCapped bool
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Errors, "; ")
+}
+
+// Preparer runs new notifications through the checks every creation goes
+// through: validation, contact and template resolution, suppression and
+// frequency caps.
+type Preparer struct {
+	notificationRepository notifications.Repository
+	templateRepository     templates.Repository
+	suppressionRepository  suppressions.Repository
+	contactRepository      contacts.Repository
+	frequencyCaps          notifications.FrequencyCaps
+}
+
+func NewPreparer(notificationRepository notifications.Repository, templateRepository templates.Repository, suppressionRepository suppressions.Repository, contactRepository contacts.Repository, frequencyCaps notifications.FrequencyCaps) *Preparer {
+	return &Preparer{
+		notificationRepository: notificationRepository,
+		templateRepository:     templateRepository,
+		suppressionRepository:  suppressionRepository,
+		contactRepository:      contactRepository,
+		frequencyCaps:          frequencyCaps,
+	}
+}
+
+// Prepare validates and resolves the notifications in place, returning the
+// errors of the ones that can not be created, keyed by their index.
+func (p *Preparer) Prepare(createNotifications []*notifications.CreateNotification) (map[int]*ValidationError, error) {
+	errs, err := p.Validate(createNotifications)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.applyFrequencyCaps(createNotifications, errs); err != nil {
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+// Validate runs the checks of Prepare but the frequency caps, which depend on
+// when the notifications are actually created.
+func (p *Preparer) Validate(createNotifications []*notifications.CreateNotification) (map[int]*ValidationError, error) {
+	errs := make(map[int]*ValidationError)
+	valid := make([]*notifications.CreateNotification, 0, len(createNotifications))
+	validIndexes := make([]int, 0, len(createNotifications))
+	contactResolver := newContactResolver(p.contactRepository)
+	templateResolver := newTemplateResolver(p.templateRepository)
+
+	for i, createNotification := range createNotifications {
+		validationError, err := resolve(contactResolver, createNotification)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if validationError == nil {
+			validationError = validateExpiresAt(createNotification.ScheduledAt, createNotification.DeliverNotBefore, createNotification.ExpiresAt)
+		}
+
+		if validationError == nil {
+			validationError, err = templateResolver.resolve(createNotification)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if validationError != nil {
+			errs[i] = validationError
+			continue
+		}
+
+		valid = append(valid, createNotification)
+		validIndexes = append(validIndexes, i)
+	}
+
+	if len(valid) == 0 {
+		return errs, nil
+	}
+
+	suppressed, err := findSuppressed(p.suppressionRepository, valid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, validationError := range suppressed {
+		errs[validIndexes[i]] = validationError
+	}
+
+	return errs, nil
+}
+
+// Resolve validates the notification and resolves its contact in place, without
+// the checks that only matter to store it, such as suppression.
+func (p *Preparer) Resolve(createNotification *notifications.CreateNotification) (*ValidationError, error) {
+	return resolve(newContactResolver(p.contactRepository), createNotification)
+}
+
+func resolve(contactResolver *contactResolver, createNotification *notifications.CreateNotification) (*ValidationError, error) {
+	validationError := validateCreateNotification(createNotification)
+
+	if validationError != nil {
+		return validationError, nil
+	}
+
+	return contactResolver.resolve(createNotification)
+}
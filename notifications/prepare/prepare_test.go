@@ -0,0 +1,57 @@
+package prepare
+
+import (
+	contactMocks "github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	notificationMocks "github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	suppressionMocks "github.com/Tagliatti/magalu-challenge/suppressions/mocks"
+	templateMocks "github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPrepare(t *testing.T) {
+	t.Run("Should key the errors by the index of the notification", func(t *testing.T) {
+		scheduledAt := time.Now().Add(time.Hour)
+		createNotifications := []*notifications.CreateNotification{
+			{Type: "sms", Recipient: "+5511999999999", Message: "Hello", ScheduledAt: scheduledAt},
+			{Type: "sms", Recipient: "test@example.com", Message: "Hello", ScheduledAt: scheduledAt},
+			{Type: "email", Recipient: "suppressed@example.com", Message: "Hello", ScheduledAt: scheduledAt},
+		}
+
+		suppressionRepository := suppressionMocks.NewRepository(t)
+		suppressionRepository.On("FindSuppressed", []suppressions.Key{
+			{Type: "sms", Recipient: "+5511999999999"},
+			{Type: "email", Recipient: "suppressed@example.com"},
+		}).Return(map[suppressions.Key]string{{Type: "email", Recipient: "suppressed@example.com"}: "bounced"}, nil)
+
+		errs, err := NewPreparer(notificationMocks.NewRepository(t), templateMocks.NewRepository(t), suppressionRepository, contactMocks.NewRepository(t), nil).
+			Prepare(createNotifications)
+
+		require.Nil(t, err)
+		require.Len(t, errs, 2)
+		assert.Contains(t, errs[1].Error(), `The field "recipient"`)
+		assert.Equal(t, []string{`The field "recipient" is suppressed for "email" notifications (bounced)`}, errs[2].Errors)
+	})
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("Should not check the suppression list", func(t *testing.T) {
+		createNotification := &notifications.CreateNotification{
+			Type:        "email",
+			Recipient:   "suppressed@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
+		}
+
+		validationError, err := NewPreparer(notificationMocks.NewRepository(t), templateMocks.NewRepository(t), suppressionMocks.NewRepository(t), contactMocks.NewRepository(t), nil).
+			Resolve(createNotification)
+
+		require.Nil(t, err)
+		assert.Nil(t, validationError)
+		assert.Equal(t, "suppressed@example.com", createNotification.Recipient)
+	})
+}
@@ -0,0 +1,60 @@
+package prepare
+
+import (
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+)
+
+// findSuppressed skips the channels of each notification whose recipient opted
+// out of them, returning the suppression error of the notifications left without
+// any channel, keyed by the notification index.
+func findSuppressed(suppressionRepository suppressions.Repository, createNotifications []*notifications.CreateNotification) (map[int]*ValidationError, error) {
+	keys := make([]suppressions.Key, 0, len(createNotifications))
+
+	for _, createNotification := range createNotifications {
+		for _, channel := range createNotification.Chain {
+			if channel.Recipient != "" {
+				keys = append(keys, suppressions.Key{Type: channel.Type, Recipient: channel.Recipient})
+			}
+		}
+	}
+
+	suppressed, err := suppressionRepository.FindSuppressed(keys)
+
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make(map[int]*ValidationError)
+
+	for i, createNotification := range createNotifications {
+		var validationError *ValidationError
+
+		for position, channel := range createNotification.Chain {
+			key := suppressions.Key{Type: channel.Type, Recipient: channel.Recipient}
+			reason, ok := suppressed[key]
+
+			if channel.Recipient == "" || !ok {
+				continue
+			}
+
+			createNotification.Chain[position].Recipient = ""
+
+			if validationError == nil {
+				validationError = newSuppressedError(key.Type, reason)
+			}
+		}
+
+		if validationError != nil && !selectChannel(createNotification) {
+			errs[i] = validationError
+		}
+	}
+
+	return errs, nil
+}
+
+func newSuppressedError(notificationType, reason string) *ValidationError {
+	return &ValidationError{
+		Errors: []string{`The field "recipient" is suppressed for "` + notificationType + `" notifications (` + reason + `)`},
+	}
+}
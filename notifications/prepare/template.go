@@ -0,0 +1,64 @@
+package prepare
+
+import (
+	"errors"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"strings"
+)
+
+var errMessageOrTemplate = errors.New(`Exactly one of the fields "message" or "template_id" must be informed`)
+var ErrTemplateNotFound = errors.New(`The field "template_id" must reference an existing template`)
+
+// templateResolver pins notifications to the current version of their template,
+// caching lookups so a batch referencing the same template hits the database once.
+type templateResolver struct {
+	templateRepository templates.Repository
+	cache              map[int64]*templates.Template
+}
+
+func newTemplateResolver(templateRepository templates.Repository) *templateResolver {
+	return &templateResolver{
+		templateRepository: templateRepository,
+		cache:              make(map[int64]*templates.Template),
+	}
+}
+
+func (r *templateResolver) resolve(createNotification *notifications.CreateNotification) (*ValidationError, error) {
+	if createNotification.TemplateId == nil {
+		return nil, nil
+	}
+
+	template, ok := r.cache[*createNotification.TemplateId]
+
+	if !ok {
+		var err error
+		template, err = r.templateRepository.FindTemplateByID(*createNotification.TemplateId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		r.cache[*createNotification.TemplateId] = template
+	}
+
+	if template == nil {
+		return &ValidationError{Errors: []string{ErrTemplateNotFound.Error()}}, nil
+	}
+
+	if missing := template.MissingVariables(createNotification.Variables); len(missing) > 0 {
+		return &ValidationError{
+			Errors: []string{`The field "variables" must have the keys: ` + strings.Join(missing, ", ")},
+		}, nil
+	}
+
+	if _, err := template.Localized(createNotification.Locale).Render(createNotification.Variables); err != nil {
+		return &ValidationError{
+			Errors: []string{`The field "variables" can not render the template: ` + err.Error()},
+		}, nil
+	}
+
+	createNotification.TemplateVersion = template.Version
+
+	return nil, nil
+}
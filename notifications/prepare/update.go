@@ -0,0 +1,77 @@
+package prepare
+
+import (
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	"time"
+)
+
+// PrepareUpdate adapts the changes to the notification, normalizing the recipient
+// for its type and refusing it when suppressed, and delaying a new schedule to
+// its send window and quiet hours, which must still be before its expiry, and
+// to its frequency cap.
+func (p *Preparer) PrepareUpdate(notification *notifications.Notification, updateNotification *notifications.UpdateNotification) (*ValidationError, error) {
+	if updateNotification.Recipient != nil {
+		recipient, err := notifications.NormalizeRecipient(notification.Type, *updateNotification.Recipient)
+
+		if err != nil {
+			return newInvalidRecipientError(err), nil
+		}
+
+		key := suppressions.Key{Type: notification.Type, Recipient: recipient}
+		suppressed, err := p.suppressionRepository.FindSuppressed([]suppressions.Key{key})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if reason, ok := suppressed[key]; ok {
+			return newSuppressedError(notification.Type, reason), nil
+		}
+
+		updateNotification.Recipient = &recipient
+	}
+
+	if updateNotification.ScheduledAt == nil {
+		return nil, nil
+	}
+
+	var deliverNotBefore time.Time
+	var timezone string
+
+	if notification.Timezone != nil {
+		timezone = *notification.Timezone
+	}
+
+	if notification.SendWindow != nil || notification.QuietHours != nil {
+		var ok bool
+		deliverNotBefore, ok = notifications.NextSendTime(*updateNotification.ScheduledAt, notification.SendWindow, timezone, notification.QuietHours)
+
+		if !ok {
+			return &ValidationError{Errors: []string{errSendWindowDuringQuietHours.Error()}}, nil
+		}
+
+		updateNotification.DeliverNotBefore = &deliverNotBefore
+	}
+
+	if validationError := validateExpiresAt(*updateNotification.ScheduledAt, deliverNotBefore, notification.ExpiresAt); validationError != nil {
+		return validationError, nil
+	}
+
+	recipient := notification.Recipient
+
+	if updateNotification.Recipient != nil {
+		recipient = *updateNotification.Recipient
+	}
+
+	return p.applyUpdateFrequencyCap(notification.Id, &notifications.CreateNotification{
+		Type:             notification.Type,
+		Recipient:        recipient,
+		ScheduledAt:      *updateNotification.ScheduledAt,
+		SendWindow:       notification.SendWindow,
+		Timezone:         timezone,
+		QuietHours:       notification.QuietHours,
+		DeliverNotBefore: deliverNotBefore,
+		ExpiresAt:        notification.ExpiresAt,
+	}, updateNotification)
+}
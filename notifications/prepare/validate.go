@@ -0,0 +1,105 @@
+package prepare
+
+import (
+	"github.com/Oudwins/zog"
+	"github.com/Oudwins/zog/internals"
+	"github.com/Oudwins/zog/zconst"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/templates"
+)
+
+var createNotificationSchema = zog.Struct(zog.Schema{
+	"type":        zog.String().Trim().OneOf(notifications.Types),
+	"recipient":   zog.String().Min(3).Max(255),
+	"priority":    zog.String().Trim().OneOf(notifications.Priorities),
+	"channels":    zog.Slice(zog.String().OneOf(notifications.Types)),
+	"contactId":   zog.Ptr(zog.Int64().GT(0)),
+	"message":     zog.String().Trim().Max(4096),
+	"templateId":  zog.Ptr(zog.Int64().GT(0)),
+	"locale":      zog.String().Trim().Match(templates.LocaleRegex, zog.Message("must be a locale such as pt-BR, en-US or es")),
+	"scheduledAt": zog.Time().Required().TestFunc(httputil.IsInTheFuture, zog.Message("must be in the future")),
+	"sendWindow":  zog.Ptr(sendWindowSchema),
+	"timezone":    zog.String().Trim().TestFunc(httputil.IsTimezone, zog.Message("must be an IANA time zone such as America/Sao_Paulo")),
+	"expiresAt":   zog.Ptr(zog.Time().TestFunc(httputil.IsInTheFuture, zog.Message("must be in the future"))),
+})
+
+func validateCreateNotification(createNotification *notifications.CreateNotification) *ValidationError {
+	validationErrors := createNotificationSchema.Validate(createNotification)
+
+	if validationErrors != nil {
+		return newValidationErrorFromZog(validationErrors)
+	}
+
+	if (createNotification.Message == "") == (createNotification.TemplateId == nil) {
+		return &ValidationError{Errors: []string{errMessageOrTemplate.Error()}}
+	}
+
+	if (createNotification.Recipient == "") == (createNotification.ContactId == nil) {
+		return &ValidationError{Errors: []string{errRecipientOrContact.Error()}}
+	}
+
+	if createNotification.Type != "" && len(createNotification.Channels) > 0 {
+		return &ValidationError{Errors: []string{errTypeOrChannels.Error()}}
+	}
+
+	if hasRepeatedChannel(createNotification.Channels) {
+		return &ValidationError{Errors: []string{errRepeatedChannel.Error()}}
+	}
+
+	if validationError := validateSendWindow(createNotification); validationError != nil {
+		return validationError
+	}
+
+	if createNotification.ContactId == nil {
+		types := channelTypes(createNotification)
+
+		if len(types) == 0 {
+			return &ValidationError{Errors: []string{errTypeRequired.Error()}}
+		}
+
+		createNotification.Chain = make(notifications.Channels, len(types))
+
+		for i, notificationType := range types {
+			recipient, err := notifications.NormalizeRecipient(notificationType, createNotification.Recipient)
+
+			if err != nil {
+				return newInvalidRecipientError(err)
+			}
+
+			createNotification.Chain[i] = notifications.Channel{Type: notificationType, Recipient: recipient}
+		}
+
+		selectChannel(createNotification)
+
+		if validationError := applySendWindow(createNotification); validationError != nil {
+			return validationError
+		}
+	}
+
+	if createNotification.Locale != "" {
+		createNotification.Locale = templates.CanonicalLocale(createNotification.Locale)
+	}
+
+	return nil
+}
+
+func newInvalidRecipientError(err error) *ValidationError {
+	return &ValidationError{Errors: []string{`The field "recipient" ` + err.Error()}}
+}
+
+func newValidationErrorFromZog(issues internals.ZogIssueMap) *ValidationError {
+	errors := make([]string, 0)
+
+	for path, pathIssues := range issues {
+		if path == zconst.ISSUE_KEY_FIRST {
+			continue
+		}
+
+		for _, issue := range pathIssues {
+			errors = append(errors, `The field "`+issue.Path+`" `+issue.Message)
+		}
+	}
+
+	return &ValidationError{Errors: errors}
+}
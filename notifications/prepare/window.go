@@ -0,0 +1,52 @@
+package prepare
+
+import (
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+)
+
+var sendWindowSchema = zog.Struct(zog.Schema{
+	"start":    zog.String().Required().Match(notifications.ClockRegex, zog.Message("must be a time such as 08:00")),
+	"end":      zog.String().Required().Match(notifications.ClockRegex, zog.Message("must be a time such as 21:00")),
+	"weekdays": zog.Slice(zog.String().OneOf(notifications.Weekdays)),
+})
+
+var errTimezoneWithoutSendWindow = errors.New(`The field "timezone" requires the field "send_window"`)
+var errEmptySendWindow = errors.New(`The field "send_window" must have different "start" and "end" times`)
+var errSendWindowDuringQuietHours = errors.New(`The field "send_window" never opens outside the quiet hours of the contact`)
+
+func validateSendWindow(createNotification *notifications.CreateNotification) *ValidationError {
+	if createNotification.SendWindow == nil {
+		if createNotification.Timezone != "" {
+			return &ValidationError{Errors: []string{errTimezoneWithoutSendWindow.Error()}}
+		}
+
+		return nil
+	}
+
+	if createNotification.SendWindow.Start == createNotification.SendWindow.End {
+		return &ValidationError{Errors: []string{errEmptySendWindow.Error()}}
+	}
+
+	return nil
+}
+
+// applySendWindow sets when the notification may be delivered, the first time
+// from its schedule inside its send window and outside the quiet hours of its
+// contact. Without either, the notification is delivered as scheduled.
+func applySendWindow(createNotification *notifications.CreateNotification) *ValidationError {
+	if createNotification.SendWindow == nil && createNotification.QuietHours == nil {
+		return nil
+	}
+
+	deliverNotBefore, ok := notifications.NextSendTime(createNotification.ScheduledAt, createNotification.SendWindow, createNotification.Timezone, createNotification.QuietHours)
+
+	if !ok {
+		return &ValidationError{Errors: []string{errSendWindowDuringQuietHours.Error()}}
+	}
+
+	createNotification.DeliverNotBefore = deliverNotBefore
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package notifications
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidEmail = errors.New("must be a valid e-mail address")
+var ErrInvalidPhoneNumber = errors.New("must be a phone number in the E.164 format, e.g. +5511999999999")
+var ErrInvalidDeviceToken = errors.New("must be a valid device token")
+
+var (
+	phoneFormattingReplacer = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+	e164Pattern             = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	apnsTokenPattern        = regexp.MustCompile(`^<?[0-9a-fA-F ]{64,}>?$`)
+	deviceTokenPattern      = regexp.MustCompile(`^[A-Za-z0-9_:.\-]{32,255}$`)
+)
+
+// NormalizeRecipient validates the recipient against the format expected by the
+// notification type, returning it in the canonical form it should be stored in.
+func NormalizeRecipient(notificationType string, recipient string) (string, error) {
+	recipient = strings.TrimSpace(recipient)
+
+	switch notificationType {
+	case "email":
+		return normalizeEmail(recipient)
+	case "sms", "whatsapp":
+		return normalizePhoneNumber(recipient)
+	case "push":
+		return normalizeDeviceToken(recipient)
+	}
+
+	return recipient, nil
+}
+
+func normalizeEmail(recipient string) (string, error) {
+	address, err := mail.ParseAddress(recipient)
+
+	if err != nil || address.Name != "" || address.Address != recipient || len(recipient) > 255 {
+		return "", ErrInvalidEmail
+	}
+
+	return strings.ToLower(address.Address), nil
+}
+
+func normalizePhoneNumber(recipient string) (string, error) {
+	phoneNumber := phoneFormattingReplacer.Replace(recipient)
+
+	if strings.HasPrefix(phoneNumber, "00") {
+		phoneNumber = "+" + strings.TrimPrefix(phoneNumber, "00")
+	}
+
+	if !e164Pattern.MatchString(phoneNumber) {
+		return "", ErrInvalidPhoneNumber
+	}
+
+	return phoneNumber, nil
+}
+
+// normalizeDeviceToken accepts FCM registration tokens as they are and APNs tokens
+// also in the "<0a1b 2c3d ...>" form printed by older iOS SDKs.
+func normalizeDeviceToken(recipient string) (string, error) {
+	token := recipient
+
+	if apnsTokenPattern.MatchString(token) {
+		token = strings.ToLower(strings.NewReplacer("<", "", ">", "", " ", "").Replace(token))
+	}
+
+	if !deviceTokenPattern.MatchString(token) {
+		return "", ErrInvalidDeviceToken
+	}
+
+	return token, nil
+}
@@ -0,0 +1,40 @@
+package notifications
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeRecipient(t *testing.T) {
+	apnsToken := strings.Repeat("0a1b2c3d", 8)
+	fcmToken := "cXl2dM5tQ0:APA91bH" + strings.Repeat("x", 140)
+
+	testCases := []struct {
+		name             string
+		notificationType string
+		recipient        string
+		expected         string
+		expectedErr      error
+	}{
+		{"Should lowercase e-mail address", "email", " Test@Example.COM ", "test@example.com", nil},
+		{"Should reject e-mail without domain", "email", "abc", "", ErrInvalidEmail},
+		{"Should reject e-mail with display name", "email", "Test <test@example.com>", "", ErrInvalidEmail},
+		{"Should strip phone number formatting", "sms", "+55 (11) 99999-9999", "+5511999999999", nil},
+		{"Should convert international prefix to plus sign", "whatsapp", "0055 11 99999 9999", "+5511999999999", nil},
+		{"Should reject phone number without country code", "sms", "11999999999", "", ErrInvalidPhoneNumber},
+		{"Should reject phone number with letters", "whatsapp", "+55abc", "", ErrInvalidPhoneNumber},
+		{"Should accept FCM token", "push", fcmToken, fcmToken, nil},
+		{"Should normalize APNs token", "push", "<" + strings.ToUpper(apnsToken[:32]) + " " + apnsToken[32:] + ">", apnsToken, nil},
+		{"Should reject short device token", "push", "abc", "", ErrInvalidDeviceToken},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recipient, err := NormalizeRecipient(tc.notificationType, tc.recipient)
+
+			assert.ErrorIs(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, recipient)
+		})
+	}
+}
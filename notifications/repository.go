@@ -2,17 +2,70 @@ package notifications
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+	"time"
 )
 
 type Repository interface {
 	CreateNotification(createNotification *CreateNotification) (int64, error)
-	UpdateNotificationAsSent(id int64) (bool, error)
+	CreateNotifications(createNotifications []*CreateNotification) ([]int64, error)
+	CreateNotificationWithIdempotencyKey(idempotencyKey *IdempotencyKey, createNotification *CreateNotification) (int64, bool, error)
+	FindIdempotentNotification(idempotencyKey *IdempotencyKey) (int64, error)
+	ClaimDueNotifications(limit int, lease time.Duration) ([]*Notification, error)
+	ReleaseNotification(id int64) (bool, error)
+	UpdateNotificationAsSent(id int64, channel string) (bool, error)
+	UpdateNotificationForRetry(id int64, nextAttemptAt time.Time, failure string) (bool, error)
+	UpdateNotificationAsFailed(id int64, failure string) (bool, error)
+	UpdateNotificationForFallback(id int64, position int, channel Channel, nextAttemptAt time.Time, failure string) (bool, error)
+	UpdateNotificationAsExpired(id int64) (bool, error)
+	ExpireNotifications(lease time.Duration) (int64, error)
+	RetryNotificationByID(id int64, nextAttemptAt time.Time) (bool, error)
+	CancelNotificationByID(id int64, reason string) (bool, error)
+	UpdateNotificationByID(id int64, updateNotification *UpdateNotification) (bool, error)
 	FindNotificationByID(id int64) (*Notification, error)
 	FindNotificationStatusByID(id int64) (*NotificationStatus, error)
+	FindRecipientDeliveries(notificationType, recipient string, since time.Time, excludeId int64) ([]time.Time, error)
+	FindRecipientsDeliveries(since map[RecipientKey]time.Time) (map[RecipientKey][]time.Time, error)
+	FindNotificationEventsByID(id int64) ([]*Event, error)
+	ListNotifications(filter *ListNotificationsFilter) (*NotificationPage, error)
 	DeleteNotificationByID(id int64) (bool, error)
 }
 
+const notificationColumns = `id, type, recipient, priority, channels, channel_position, sent_channel, contact_id, recurring_notification_id, message, template_id, template_version, variables, locale, scheduled_at, send_window, timezone, quiet_hours, deliver_not_before, expires_at, created_at, status, sent_at, attempts, next_attempt_at, last_error`
+
+type assignment struct {
+	column string
+	value  any
+}
+
+// change describes a status transition: the event recorded on the timeline
+// (defaults to the next status), who caused it, and any extra columns to update.
+// countAttempt marks the end of a send attempt, incrementing attempts.
+type change struct {
+	event        string
+	actor        string
+	failure      string
+	countAttempt bool
+	assignments  []assignment
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+type rowQuerier interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// Querier is satisfied by both *sql.DB and *sql.Tx.
+type Querier interface {
+	Query(query string, args ...any) (*sql.Rows, error)
+}
+
 type PostgresRepository struct {
 	db *sql.DB
 }
@@ -22,47 +75,515 @@ func NewPostgresRepository(db *sql.DB) *PostgresRepository {
 }
 
 func (r *PostgresRepository) CreateNotification(createNotification *CreateNotification) (int64, error) {
+	return insertNotification(r.db, createNotification)
+}
+
+// CreateNotifications inserts all notifications in a single statement, returning
+// their ids in the same order as the input.
+func (r *PostgresRepository) CreateNotifications(createNotifications []*CreateNotification) ([]int64, error) {
+	return InsertNotifications(r.db, createNotifications)
+}
+
+// InsertNotifications creates the notifications through q, which may be a
+// transaction so other packages can create them along with their own changes.
+func InsertNotifications(q Querier, createNotifications []*CreateNotification) ([]int64, error) {
+	types := make([]string, len(createNotifications))
+	recipients := make([]string, len(createNotifications))
+	messages := make([]string, len(createNotifications))
+	scheduledAts := make([]string, len(createNotifications))
+	templateIds := make([]sql.NullInt64, len(createNotifications))
+	templateVersions := make([]sql.NullInt64, len(createNotifications))
+	variables := make([]sql.NullString, len(createNotifications))
+	locales := make([]string, len(createNotifications))
+	contactIds := make([]sql.NullInt64, len(createNotifications))
+	chains := make([]string, len(createNotifications))
+	positions := make([]int, len(createNotifications))
+	sendWindows := make([]sql.NullString, len(createNotifications))
+	timezones := make([]string, len(createNotifications))
+	deliverNotBefores := make([]string, len(createNotifications))
+	recurringNotificationIds := make([]sql.NullInt64, len(createNotifications))
+	expiresAts := make([]sql.NullTime, len(createNotifications))
+	priorities := make([]string, len(createNotifications))
+	quietHours := make([]sql.NullString, len(createNotifications))
+
+	for i, createNotification := range createNotifications {
+		types[i] = createNotification.Type
+		recipients[i] = createNotification.Recipient
+		messages[i] = createNotification.Message
+		scheduledAts[i] = createNotification.ScheduledAt.Format(time.RFC3339Nano)
+		locales[i] = createNotification.Locale
+		timezones[i] = createNotification.Timezone
+		deliverNotBefores[i] = deliverNotBefore(createNotification).Format(time.RFC3339Nano)
+		priorities[i] = priority(createNotification)
+
+		if createNotification.TemplateId != nil {
+			templateIds[i] = sql.NullInt64{Int64: *createNotification.TemplateId, Valid: true}
+			templateVersions[i] = sql.NullInt64{Int64: int64(createNotification.TemplateVersion), Valid: true}
+		}
+
+		encodedChain, err := json.Marshal(chain(createNotification))
+
+		if err != nil {
+			return nil, err
+		}
+
+		chains[i] = string(encodedChain)
+		positions[i] = createNotification.ChannelPosition
+
+		if createNotification.ContactId != nil {
+			contactIds[i] = sql.NullInt64{Int64: *createNotification.ContactId, Valid: true}
+		}
+
+		if createNotification.RecurringNotificationId != nil {
+			recurringNotificationIds[i] = sql.NullInt64{Int64: *createNotification.RecurringNotificationId, Valid: true}
+		}
+
+		if createNotification.ExpiresAt != nil {
+			expiresAts[i] = sql.NullTime{Time: *createNotification.ExpiresAt, Valid: true}
+		}
+
+		if createNotification.Variables != nil {
+			encoded, err := json.Marshal(createNotification.Variables)
+
+			if err != nil {
+				return nil, err
+			}
+
+			variables[i] = sql.NullString{String: string(encoded), Valid: true}
+		}
+
+		if createNotification.SendWindow != nil {
+			encoded, err := json.Marshal(createNotification.SendWindow)
+
+			if err != nil {
+				return nil, err
+			}
+
+			sendWindows[i] = sql.NullString{String: string(encoded), Valid: true}
+		}
+
+		if createNotification.QuietHours != nil {
+			encoded, err := json.Marshal(createNotification.QuietHours)
+
+			if err != nil {
+				return nil, err
+			}
+
+			quietHours[i] = sql.NullString{String: string(encoded), Valid: true}
+		}
+	}
+
+	rows, err := q.Query(`
+		WITH input AS (
+			SELECT * FROM unnest($1::notification_type[], $2::varchar[], $3::text[], $4::timestamptz[], $7::bigint[], $8::integer[], $9::jsonb[], $10::varchar[], $11::bigint[], $12::jsonb[], $13::integer[], $14::jsonb[], $15::varchar[], $16::timestamptz[], $17::bigint[], $18::timestamptz[], $19::notification_priority[], $20::jsonb[])
+				WITH ORDINALITY AS input (type, recipient, message, scheduled_at, template_id, template_version, variables, locale, contact_id, channels, channel_position, send_window, timezone, deliver_not_before, recurring_notification_id, expires_at, priority, quiet_hours, position)
+		), created AS (
+			INSERT INTO notifications (type, recipient, message, scheduled_at, template_id, template_version, variables, locale, contact_id, channels, channel_position, send_window, timezone, deliver_not_before, recurring_notification_id, expires_at, priority, quiet_hours)
+			SELECT type, recipient, NULLIF(message, ''), scheduled_at, template_id, template_version, variables, NULLIF(locale, ''), contact_id, channels, channel_position, send_window, NULLIF(timezone, ''), deliver_not_before, recurring_notification_id, expires_at, priority, quiet_hours
+			FROM input ORDER BY position
+			RETURNING id, status
+		), event AS (
+			INSERT INTO notification_events (notification_id, event, status, actor) SELECT id, $5, status, $6 FROM created
+		)
+		SELECT id FROM created ORDER BY id`,
+		pq.Array(types),
+		pq.Array(recipients),
+		pq.Array(messages),
+		pq.Array(scheduledAts),
+		EventCreated,
+		ActorAPI,
+		pq.Array(templateIds),
+		pq.Array(templateVersions),
+		pq.Array(variables),
+		pq.Array(locales),
+		pq.Array(contactIds),
+		pq.Array(chains),
+		pq.Array(positions),
+		pq.Array(sendWindows),
+		pq.Array(timezones),
+		pq.Array(deliverNotBefores),
+		pq.Array(recurringNotificationIds),
+		pq.Array(expiresAts),
+		pq.Array(priorities),
+		pq.Array(quietHours),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int64, 0, len(createNotifications))
+
+	for rows.Next() {
+		var id int64
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// CreateNotificationWithIdempotencyKey creates the notification only the first time the
+// key is seen for the client. Replays return the original id and true, while reusing
+// the key for a different request fails with ErrIdempotencyKeyReused.
+func (r *PostgresRepository) CreateNotificationWithIdempotencyKey(idempotencyKey *IdempotencyKey, createNotification *CreateNotification) (int64, bool, error) {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`INSERT INTO idempotency_keys (client_id, key, request_hash) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+		idempotencyKey.ClientId,
+		idempotencyKey.Key,
+		idempotencyKey.RequestHash,
+	)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	inserted, err := result.RowsAffected()
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	if inserted == 0 {
+		var requestHash string
+		var id int64
+
+		err = tx.QueryRow(`SELECT request_hash, notification_id FROM idempotency_keys WHERE client_id = $1 AND key = $2`,
+			idempotencyKey.ClientId,
+			idempotencyKey.Key,
+		).Scan(&requestHash, &id)
+
+		if err != nil {
+			return 0, false, err
+		}
+
+		if requestHash != idempotencyKey.RequestHash {
+			return 0, false, ErrIdempotencyKeyReused
+		}
+
+		return id, true, nil
+	}
+
+	id, err := insertNotification(tx, createNotification)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	_, err = tx.Exec(`UPDATE idempotency_keys SET notification_id = $3 WHERE client_id = $1 AND key = $2`,
+		idempotencyKey.ClientId,
+		idempotencyKey.Key,
+		id,
+	)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, false, err
+	}
+
+	return id, false, nil
+}
+
+// FindIdempotentNotification returns the id of the notification already created with
+// the key, or zero when the key was not used yet. Using the key for a different
+// request fails with ErrIdempotencyKeyReused.
+func (r *PostgresRepository) FindIdempotentNotification(idempotencyKey *IdempotencyKey) (int64, error) {
+	var requestHash string
 	var id int64
 
-	err := r.db.QueryRow(`INSERT INTO notifications (type, recipient) VALUES ($1, $2) RETURNING id`,
-		createNotification.Type,
-		createNotification.Recipient,
-	).Scan(&id)
+	err := r.db.QueryRow(`SELECT request_hash, notification_id FROM idempotency_keys WHERE client_id = $1 AND key = $2 AND notification_id IS NOT NULL`,
+		idempotencyKey.ClientId,
+		idempotencyKey.Key,
+	).Scan(&requestHash, &id)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
 
 	if err != nil {
 		return 0, err
 	}
 
+	if requestHash != idempotencyKey.RequestHash {
+		return 0, ErrIdempotencyKeyReused
+	}
+
 	return id, nil
 }
 
-func (r *PostgresRepository) UpdateNotificationAsSent(id int64) (bool, error) {
-	result, err := r.db.Exec(`UPDATE notifications SET sent_at = NOW() WHERE id = $1 and sent_at is null`, id)
+// ClaimDueNotifications moves up to limit due notifications to sending, the most
+// urgent first. Notifications left in sending for longer than lease (e.g. the
+// replica holding them crashed) are claimed again.
+func (r *PostgresRepository) ClaimDueNotifications(limit int, lease time.Duration) ([]*Notification, error) {
+	rows, err := r.db.Query(`
+		WITH claimed AS (
+			UPDATE notifications SET status = 'sending', sending_at = NOW()
+			WHERE id IN (
+				SELECT id FROM notifications
+				WHERE ((status IN ('scheduled', 'queued') AND COALESCE(next_attempt_at, deliver_not_before) <= NOW())
+				   OR (status = 'sending' AND sending_at < NOW() - make_interval(secs => $2)))
+				  AND (expires_at IS NULL OR expires_at > NOW())
+				ORDER BY priority, COALESCE(next_attempt_at, deliver_not_before)
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING `+notificationColumns+`
+		), event AS (
+			INSERT INTO notification_events (notification_id, event, status, attempt, actor)
+			SELECT id, 'sending', status, attempts + 1, $3 FROM claimed
+		)
+		SELECT `+notificationColumns+` FROM claimed ORDER BY priority, COALESCE(next_attempt_at, deliver_not_before)`,
+		limit,
+		lease.Seconds(),
+		ActorDispatcher,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	claimed := make([]*Notification, 0, limit)
+
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		claimed = append(claimed, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+func (r *PostgresRepository) ReleaseNotification(id int64) (bool, error) {
+	return r.transition(id, StatusQueued, change{actor: ActorDispatcher})
+}
+
+// UpdateNotificationAsSent marks the notification as delivered on channel, the
+// one of its fallback chain that succeeded.
+func (r *PostgresRepository) UpdateNotificationAsSent(id int64, channel string) (bool, error) {
+	return r.transition(id, StatusSent, change{
+		actor:        ActorDispatcher,
+		countAttempt: true,
+		assignments:  []assignment{{"sent_channel", channel}},
+	})
+}
+
+func (r *PostgresRepository) UpdateNotificationForRetry(id int64, nextAttemptAt time.Time, failure string) (bool, error) {
+	return r.transition(id, StatusQueued, change{
+		event:        EventAttemptFailed,
+		actor:        ActorDispatcher,
+		failure:      failure,
+		countAttempt: true,
+		assignments: []assignment{
+			{"next_attempt_at", nextAttemptAt},
+			{"last_error", failure},
+		},
+	})
+}
+
+func (r *PostgresRepository) UpdateNotificationAsFailed(id int64, failure string) (bool, error) {
+	return r.transition(id, StatusFailed, change{
+		actor:        ActorDispatcher,
+		failure:      failure,
+		countAttempt: true,
+		assignments: []assignment{
+			{"next_attempt_at", nil},
+			{"last_error", failure},
+		},
+	})
+}
+
+// UpdateNotificationForFallback re-queues the notification for sending at
+// nextAttemptAt on the channel at position of its fallback chain, with a fresh
+// set of attempts.
+func (r *PostgresRepository) UpdateNotificationForFallback(id int64, position int, channel Channel, nextAttemptAt time.Time, failure string) (bool, error) {
+	return r.transition(id, StatusQueued, change{
+		event:   EventFallback,
+		actor:   ActorDispatcher,
+		failure: failure,
+		assignments: []assignment{
+			{"type", channel.Type},
+			{"recipient", channel.Recipient},
+			{"channel_position", position},
+			{"attempts", 0},
+			{"next_attempt_at", nextAttemptAt},
+			{"last_error", failure},
+		},
+	})
+}
+
+// UpdateNotificationAsExpired gives up on a claimed notification whose expiry
+// passed before it could be sent.
+func (r *PostgresRepository) UpdateNotificationAsExpired(id int64) (bool, error) {
+	return r.transition(id, StatusExpired, change{
+		actor:       ActorDispatcher,
+		assignments: []assignment{{"next_attempt_at", nil}},
+	})
+}
+
+// ExpireNotifications moves the notifications not sent before their expiry to
+// expired, including the ones left in sending for longer than lease, returning
+// how many expired.
+func (r *PostgresRepository) ExpireNotifications(lease time.Duration) (int64, error) {
+	result, err := r.db.Exec(`
+		WITH expired AS (
+			UPDATE notifications SET status = 'expired', expired_at = NOW(), next_attempt_at = NULL
+			WHERE expires_at <= NOW()
+			  AND (status IN ('scheduled', 'queued') OR (status = 'sending' AND sending_at < NOW() - make_interval(secs => $1)))
+			RETURNING id, status
+		)
+		INSERT INTO notification_events (notification_id, event, status, actor)
+		SELECT id, 'expired', status, $2 FROM expired`,
+		lease.Seconds(),
+		ActorDispatcher,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// RetryNotificationByID re-queues a failed notification for sending at
+// nextAttemptAt, giving it a fresh set of attempts.
+func (r *PostgresRepository) RetryNotificationByID(id int64, nextAttemptAt time.Time) (bool, error) {
+	return r.transition(id, StatusQueued, change{
+		event: EventRetried,
+		actor: ActorAPI,
+		assignments: []assignment{
+			{"attempts", 0},
+			{"next_attempt_at", nextAttemptAt},
+		},
+	})
+}
+
+func (r *PostgresRepository) CancelNotificationByID(id int64, reason string) (bool, error) {
+	var cancelReason *string
+
+	if reason != "" {
+		cancelReason = &reason
+	}
+
+	return r.transition(id, StatusCanceled, change{
+		actor:       ActorAPI,
+		assignments: []assignment{{"cancel_reason", cancelReason}},
+	})
+}
+
+// UpdateNotificationByID changes the given fields of a pending notification, returning
+// false when it does not exist and ErrNotificationNotPending once it was picked up
+// for sending or finished.
+func (r *PostgresRepository) UpdateNotificationByID(id int64, updateNotification *UpdateNotification) (bool, error) {
+	tx, err := r.db.Begin()
 
 	if err != nil {
 		return false, err
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	var current Status
+	err = tx.QueryRow(`SELECT status FROM notifications WHERE id = $1 FOR UPDATE`, id).Scan(&current)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
 		return false, err
 	}
 
-	return rowsAffected > 0, nil
+	if !current.IsPending() {
+		return false, ErrNotificationNotPending
+	}
+
+	assignments := make([]string, 0)
+	args := []any{id}
+
+	set := func(column string, value any) {
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if updateNotification.Recipient != nil {
+		set("recipient", *updateNotification.Recipient)
+		assignments = append(assignments, fmt.Sprintf("channels = jsonb_set(channels, ARRAY[channel_position::text, 'recipient'], to_jsonb($%d::text))", len(args)))
+	}
+
+	if updateNotification.Message != nil {
+		set("message", *updateNotification.Message)
+		set("template_id", nil)
+		set("template_version", nil)
+		set("variables", nil)
+	}
+
+	if updateNotification.ScheduledAt != nil {
+		deliverNotBefore := updateNotification.ScheduledAt
+
+		if updateNotification.DeliverNotBefore != nil {
+			deliverNotBefore = updateNotification.DeliverNotBefore
+		}
+
+		set("scheduled_at", *updateNotification.ScheduledAt)
+		set("deliver_not_before", *deliverNotBefore)
+		set("next_attempt_at", nil)
+	}
+
+	if len(assignments) > 0 {
+		_, err = tx.Exec(`UPDATE notifications SET `+strings.Join(assignments, ", ")+` WHERE id = $1`, args...)
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	_, err = tx.Exec(`INSERT INTO notification_events (notification_id, event, status, actor) VALUES ($1, $2, $3, $4)`,
+		id,
+		EventUpdated,
+		current,
+		ActorAPI,
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
 }
 
 func (r *PostgresRepository) FindNotificationByID(id int64) (*Notification, error) {
-	var notification Notification
-	row := r.db.QueryRow(`SELECT id, type, recipient, created_at, (sent_at is not null) AS sent, sent_at FROM notifications WHERE id = $1`, id)
-	err := row.Scan(
-		&notification.Id,
-		&notification.Type,
-		&notification.Recipient,
-		&notification.CreatedAt,
-		&notification.Sent,
-		&notification.SentAt,
-	)
+	row := r.db.QueryRow(`SELECT `+notificationColumns+` FROM notifications WHERE id = $1`, id)
+	notification, err := scanNotification(row)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -72,15 +593,36 @@ func (r *PostgresRepository) FindNotificationByID(id int64) (*Notification, erro
 		return nil, err
 	}
 
-	return &notification, nil
+	return notification, nil
 }
 
 func (r *PostgresRepository) FindNotificationStatusByID(id int64) (*NotificationStatus, error) {
 	var notification NotificationStatus
-	row := r.db.QueryRow(`SELECT (sent_at is not null) AS sent, sent_at FROM notifications WHERE id = $1`, id)
+	row := r.db.QueryRow(`
+		SELECT status, type, recipient, channels, channel_position, sent_channel, created_at, scheduled_at, deliver_not_before, expires_at, queued_at, sending_at, sent_at, failed_at, canceled_at, cancel_reason, expired_at,
+		       attempts, next_attempt_at, last_error
+		FROM notifications WHERE id = $1`, id)
 	err := row.Scan(
-		&notification.Sent,
+		&notification.Status,
+		&notification.Type,
+		&notification.Recipient,
+		&notification.Channels,
+		&notification.ChannelPosition,
+		&notification.SentChannel,
+		&notification.CreatedAt,
+		&notification.ScheduledAt,
+		&notification.DeliverNotBefore,
+		&notification.ExpiresAt,
+		&notification.QueuedAt,
+		&notification.SendingAt,
 		&notification.SentAt,
+		&notification.FailedAt,
+		&notification.CanceledAt,
+		&notification.CancelReason,
+		&notification.ExpiredAt,
+		&notification.Attempts,
+		&notification.NextAttemptAt,
+		&notification.LastError,
 	)
 
 	if err != nil {
@@ -94,6 +636,240 @@ func (r *PostgresRepository) FindNotificationStatusByID(id int64) (*Notification
 	return &notification, nil
 }
 
+// FindRecipientDeliveries returns, in order, when the notifications of the type
+// to the recipient that were not given up on are delivered after since, which is
+// when they were sent or, for the pending ones, when they may be sent.
+func (r *PostgresRepository) FindRecipientDeliveries(notificationType, recipient string, since time.Time, excludeId int64) ([]time.Time, error) {
+	rows, err := r.db.Query(`
+		SELECT COALESCE(sent_at, deliver_not_before) AS delivery FROM notifications
+		WHERE type = $1 AND recipient = $2 AND id <> $4
+		  AND status IN ('scheduled', 'queued', 'sending', 'sent')
+		  AND COALESCE(sent_at, deliver_not_before) > $3
+		ORDER BY delivery`,
+		notificationType,
+		recipient,
+		since,
+		excludeId,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]time.Time, 0)
+
+	for rows.Next() {
+		var delivery time.Time
+
+		if err := rows.Scan(&delivery); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// FindRecipientsDeliveries is FindRecipientDeliveries for several recipients at
+// once, each with its own since, in a single query. Recipients without deliveries
+// are mapped to an empty slice.
+func (r *PostgresRepository) FindRecipientsDeliveries(since map[RecipientKey]time.Time) (map[RecipientKey][]time.Time, error) {
+	types := make([]string, 0, len(since))
+	recipients := make([]string, 0, len(since))
+	starts := make([]time.Time, 0, len(since))
+	deliveries := make(map[RecipientKey][]time.Time, len(since))
+
+	for key, start := range since {
+		types = append(types, key.Type)
+		recipients = append(recipients, key.Recipient)
+		starts = append(starts, start)
+		deliveries[key] = make([]time.Time, 0)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT n.type, n.recipient, COALESCE(n.sent_at, n.deliver_not_before) AS delivery
+		FROM notifications n
+		JOIN unnest($1::notification_type[], $2::varchar[], $3::timestamptz[]) AS k (type, recipient, since) ON k.type = n.type AND k.recipient = n.recipient
+		WHERE n.status IN ('scheduled', 'queued', 'sending', 'sent')
+		  AND COALESCE(n.sent_at, n.deliver_not_before) > k.since
+		ORDER BY delivery`,
+		pq.Array(types),
+		pq.Array(recipients),
+		pq.Array(starts),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key RecipientKey
+		var delivery time.Time
+
+		if err := rows.Scan(&key.Type, &key.Recipient, &delivery); err != nil {
+			return nil, err
+		}
+
+		deliveries[key] = append(deliveries[key], delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// FindNotificationEventsByID returns the notification timeline from oldest to newest,
+// or nil when the notification does not exist.
+func (r *PostgresRepository) FindNotificationEventsByID(id int64) ([]*Event, error) {
+	var exists bool
+	err := r.db.QueryRow(`SELECT EXISTS (SELECT 1 FROM notifications WHERE id = $1)`, id).Scan(&exists)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(`
+		SELECT id, created_at, event, status, attempt, error, actor
+		FROM notification_events WHERE notification_id = $1 ORDER BY id`, id)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*Event, 0)
+
+	for rows.Next() {
+		var event Event
+		err := rows.Scan(
+			&event.Id,
+			&event.CreatedAt,
+			&event.Event,
+			&event.Status,
+			&event.Attempt,
+			&event.Error,
+			&event.Actor,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// ListNotifications returns the notifications matching filter from newest to oldest,
+// paginated by id: NextCursor, when set, is the Cursor of the following page.
+func (r *PostgresRepository) ListNotifications(filter *ListNotificationsFilter) (*NotificationPage, error) {
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+
+	where := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Type != "" {
+		where("type = $%d", filter.Type)
+	}
+
+	if filter.Recipient != "" {
+		where("recipient = $%d", filter.Recipient)
+	}
+
+	if filter.ContactId > 0 {
+		where("contact_id = $%d", filter.ContactId)
+	}
+
+	if filter.RecurringNotificationId > 0 {
+		where("recurring_notification_id = $%d", filter.RecurringNotificationId)
+	}
+
+	if filter.Status != "" {
+		where("status = $%d", filter.Status)
+	}
+
+	if !filter.CreatedFrom.IsZero() {
+		where("created_at >= $%d", filter.CreatedFrom)
+	}
+
+	if !filter.CreatedTo.IsZero() {
+		where("created_at < $%d", filter.CreatedTo)
+	}
+
+	if !filter.ScheduledFrom.IsZero() {
+		where("scheduled_at >= $%d", filter.ScheduledFrom)
+	}
+
+	if !filter.ScheduledTo.IsZero() {
+		where("scheduled_at < $%d", filter.ScheduledTo)
+	}
+
+	if filter.Cursor > 0 {
+		where("id < $%d", filter.Cursor)
+	}
+
+	query := `SELECT ` + notificationColumns + ` FROM notifications`
+
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	// One extra row tells whether there is a next page.
+	args = append(args, filter.Limit+1)
+	query += fmt.Sprintf(` ORDER BY id DESC LIMIT $%d`, len(args))
+
+	rows, err := r.db.Query(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	page := &NotificationPage{Data: make([]*Notification, 0, filter.Limit)}
+
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		page.Data = append(page.Data, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(page.Data) > filter.Limit {
+		page.Data = page.Data[:filter.Limit]
+		page.NextCursor = &page.Data[filter.Limit-1].Id
+	}
+
+	return page, nil
+}
+
 func (r *PostgresRepository) DeleteNotificationByID(id int64) (bool, error) {
 	result, err := r.db.Exec(`DELETE FROM notifications WHERE id = $1`, id)
 	if err != nil {
@@ -108,3 +884,197 @@ func (r *PostgresRepository) DeleteNotificationByID(id int64) (bool, error) {
 
 	return rowsAffected > 0, nil
 }
+
+// transition moves the notification to the next status and records the change on
+// its timeline. It returns false when the notification does not exist and
+// ErrInvalidStatusTransition when the move is not allowed from its current status.
+func (r *PostgresRepository) transition(id int64, next Status, change change) (bool, error) {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var current Status
+	err = tx.QueryRow(`SELECT status FROM notifications WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if !current.CanTransitionTo(next) {
+		return false, ErrInvalidStatusTransition
+	}
+
+	query := `UPDATE notifications SET status = $2, ` + statusTimestampColumns[next] + ` = NOW()`
+	args := []any{id, next}
+
+	if change.countAttempt {
+		query += `, attempts = attempts + 1`
+	}
+
+	for _, assignment := range change.assignments {
+		args = append(args, assignment.value)
+		query += fmt.Sprintf(", %s = $%d", assignment.column, len(args))
+	}
+
+	_, err = tx.Exec(query+` WHERE id = $1`, args...)
+
+	if err != nil {
+		return false, err
+	}
+
+	event := change.event
+
+	if event == "" {
+		event = string(next)
+	}
+
+	var eventError *string
+
+	if change.failure != "" {
+		eventError = &change.failure
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO notification_events (notification_id, event, status, error, attempt, actor)
+		SELECT id, $2, status, $3, CASE WHEN $4 THEN attempts END, $5 FROM notifications WHERE id = $1`,
+		id,
+		event,
+		eventError,
+		change.countAttempt,
+		change.actor,
+	)
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func scanNotification(row rowScanner) (*Notification, error) {
+	var notification Notification
+	var message sql.NullString
+	err := row.Scan(
+		&notification.Id,
+		&notification.Type,
+		&notification.Recipient,
+		&notification.Priority,
+		&notification.Channels,
+		&notification.ChannelPosition,
+		&notification.SentChannel,
+		&notification.ContactId,
+		&notification.RecurringNotificationId,
+		&message,
+		&notification.TemplateId,
+		&notification.TemplateVersion,
+		&notification.Variables,
+		&notification.Locale,
+		&notification.ScheduledAt,
+		&notification.SendWindow,
+		&notification.Timezone,
+		&notification.QuietHours,
+		&notification.DeliverNotBefore,
+		&notification.ExpiresAt,
+		&notification.CreatedAt,
+		&notification.Status,
+		&notification.SentAt,
+		&notification.Attempts,
+		&notification.NextAttemptAt,
+		&notification.LastError,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	notification.Message = message.String
+
+	return &notification, nil
+}
+
+func insertNotification(q rowQuerier, createNotification *CreateNotification) (int64, error) {
+	var id int64
+
+	err := q.QueryRow(`
+		WITH created AS (
+			INSERT INTO notifications (type, recipient, message, scheduled_at, template_id, template_version, variables, locale, contact_id, channels, channel_position, send_window, timezone, deliver_not_before, recurring_notification_id, expires_at, priority, quiet_hours)
+			VALUES ($1, $2, NULLIF($3, ''), $4, $7, $8, $9, NULLIF($10, ''), $11, $12, $13, $14, NULLIF($15, ''), $16, $17, $18, $19, $20) RETURNING id, status
+		), event AS (
+			INSERT INTO notification_events (notification_id, event, status, actor) SELECT id, $5, status, $6 FROM created
+		)
+		SELECT id FROM created`,
+		createNotification.Type,
+		createNotification.Recipient,
+		createNotification.Message,
+		createNotification.ScheduledAt,
+		EventCreated,
+		ActorAPI,
+		createNotification.TemplateId,
+		templateVersion(createNotification),
+		createNotification.Variables,
+		createNotification.Locale,
+		createNotification.ContactId,
+		chain(createNotification),
+		createNotification.ChannelPosition,
+		createNotification.SendWindow,
+		createNotification.Timezone,
+		deliverNotBefore(createNotification),
+		createNotification.RecurringNotificationId,
+		createNotification.ExpiresAt,
+		priority(createNotification),
+		createNotification.QuietHours,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func templateVersion(createNotification *CreateNotification) *int {
+	if createNotification.TemplateId == nil {
+		return nil
+	}
+
+	return &createNotification.TemplateVersion
+}
+
+// chain returns the fallback chain of the notification, which is the single
+// channel of its type when none was resolved.
+func chain(createNotification *CreateNotification) Channels {
+	if len(createNotification.Chain) == 0 {
+		return Channels{{Type: createNotification.Type, Recipient: createNotification.Recipient}}
+	}
+
+	return createNotification.Chain
+}
+
+// deliverNotBefore returns the first time the notification may be sent, which
+// is its schedule when no send window shifted it.
+func deliverNotBefore(createNotification *CreateNotification) time.Time {
+	if createNotification.DeliverNotBefore.IsZero() {
+		return createNotification.ScheduledAt
+	}
+
+	return createNotification.DeliverNotBefore
+}
+
+func priority(createNotification *CreateNotification) string {
+	if createNotification.Priority == "" {
+		return DefaultPriority
+	}
+
+	return createNotification.Priority
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"strings"
 	"testing"
+	"time"
 )
 
 type PostgresRepositoryTestSuite struct {
@@ -52,8 +54,10 @@ func (suite *PostgresRepositoryTestSuite) TestSuccessCreateNotification() {
 		require.Nilf(t, err, "failed to truncate tables: %v", err)
 
 		createNotification := &CreateNotification{
-			Type:      "email",
-			Recipient: "test@example.com",
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
 		}
 
 		id, err := suite.repository.CreateNotification(createNotification)
@@ -66,8 +70,10 @@ func (suite *PostgresRepositoryTestSuite) TestSuccessCreateNotification() {
 		assert.Equal(t, id, notification.Id)
 		assert.Equal(t, createNotification.Type, notification.Type)
 		assert.Equal(t, createNotification.Recipient, notification.Recipient)
+		assert.Equal(t, createNotification.Message, notification.Message)
+		assert.WithinDuration(t, createNotification.ScheduledAt, notification.ScheduledAt, time.Millisecond)
 		assert.NotEmpty(t, notification.CreatedAt)
-		assert.False(t, notification.Sent)
+		assert.Equal(t, StatusScheduled, notification.Status)
 		assert.Nil(t, notification.SentAt)
 	})
 }
@@ -80,8 +86,10 @@ func (suite *PostgresRepositoryTestSuite) TestErrorOnCreateNotificationWithInval
 		require.Nilf(t, err, "failed to truncate tables: %v", err)
 
 		createNotification := &CreateNotification{
-			Type:      "invalid",
-			Recipient: "test@example.com",
+			Type:        "invalid",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
 		}
 
 		id, err := suite.repository.CreateNotification(createNotification)
@@ -90,6 +98,176 @@ func (suite *PostgresRepositoryTestSuite) TestErrorOnCreateNotificationWithInval
 	})
 }
 
+func (suite *PostgresRepositoryTestSuite) TestSuccessCreateNotificationFromTemplate() {
+	t := suite.T()
+
+	t.Run("Should create notification referencing a template version", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		var templateId int64
+		err = suite.db.QueryRow(`
+			WITH created AS (
+				INSERT INTO templates (name) VALUES ('order-shipped') RETURNING id
+			), version AS (
+				INSERT INTO template_versions (template_id, version, body, variables) SELECT id, 1, 'Olá, {{.name}}', '{name}' FROM created
+			)
+			SELECT id FROM created`).Scan(&templateId)
+		require.Nilf(t, err, "failed to create template: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:            "email",
+			Recipient:       "test@example.com",
+			TemplateId:      &templateId,
+			TemplateVersion: 1,
+			Variables:       Variables{"name": "Maria"},
+			Locale:          "pt-BR",
+			ScheduledAt:     time.Now().Add(time.Hour),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		notification, err := suite.repository.FindNotificationByID(id)
+		require.Nilf(t, err, "failed to find notification by ID: %v", err)
+		require.NotNil(t, notification, "notification should not be nil")
+
+		assert.Equal(t, "", notification.Message)
+		assert.Equal(t, templateId, *notification.TemplateId)
+		assert.Equal(t, 1, *notification.TemplateVersion)
+		assert.Equal(t, Variables{"name": "Maria"}, notification.Variables)
+		require.NotNil(t, notification.Locale)
+		assert.Equal(t, "pt-BR", *notification.Locale)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessCreateNotifications() {
+	t := suite.T()
+
+	t.Run("Should create notifications in batch keeping the input order", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		createNotifications := make([]*CreateNotification, 0)
+
+		for _, recipient := range []string{"first@example.com", "second@example.com", "third@example.com"} {
+			createNotifications = append(createNotifications, &CreateNotification{
+				Type:        "email",
+				Recipient:   recipient,
+				Message:     "Hello",
+				ScheduledAt: time.Now().Add(time.Hour),
+			})
+		}
+
+		ids, err := suite.repository.CreateNotifications(createNotifications)
+		require.Nilf(t, err, "failed to create notifications: %v", err)
+		require.Len(t, ids, len(createNotifications))
+
+		for i, id := range ids {
+			notification, err := suite.repository.FindNotificationByID(id)
+			require.Nilf(t, err, "failed to find notification by ID: %v", err)
+			require.NotNil(t, notification, "notification should not be nil")
+
+			assert.Equal(t, createNotifications[i].Recipient, notification.Recipient)
+			assert.Equal(t, StatusScheduled, notification.Status)
+
+			events, err := suite.repository.FindNotificationEventsByID(id)
+			require.Nilf(t, err, "failed to find notification events: %v", err)
+			require.Len(t, events, 1)
+
+			assert.Equal(t, EventCreated, events[0].Event)
+		}
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessCreateNotificationWithIdempotencyKey() {
+	t := suite.T()
+
+	t.Run("Should create notification only once per idempotency key", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		createNotification := &CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
+		}
+		idempotencyKey := &IdempotencyKey{ClientId: "checkout", Key: "order-42", RequestHash: strings.Repeat("a", 64)}
+
+		id, replayed, err := suite.repository.CreateNotificationWithIdempotencyKey(idempotencyKey, createNotification)
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		assert.False(t, replayed)
+
+		replayedId, replayed, err := suite.repository.CreateNotificationWithIdempotencyKey(idempotencyKey, createNotification)
+		require.Nilf(t, err, "failed to replay notification: %v", err)
+
+		assert.True(t, replayed)
+		assert.Equal(t, id, replayedId)
+
+		otherClientId, replayed, err := suite.repository.CreateNotificationWithIdempotencyKey(&IdempotencyKey{
+			ClientId:    "billing",
+			Key:         idempotencyKey.Key,
+			RequestHash: idempotencyKey.RequestHash,
+		}, createNotification)
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		assert.False(t, replayed)
+		assert.NotEqual(t, id, otherClientId)
+	})
+
+	t.Run("Should reject idempotency key reused with a different request", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		createNotification := &CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
+		}
+
+		_, _, err = suite.repository.CreateNotificationWithIdempotencyKey(&IdempotencyKey{Key: "order-42", RequestHash: strings.Repeat("a", 64)}, createNotification)
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		id, replayed, err := suite.repository.CreateNotificationWithIdempotencyKey(&IdempotencyKey{Key: "order-42", RequestHash: strings.Repeat("b", 64)}, createNotification)
+
+		assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+		assert.False(t, replayed)
+		assert.Zero(t, id)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessFindIdempotentNotification() {
+	t := suite.T()
+
+	t.Run("Should find the notification created with the idempotency key", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		idempotencyKey := &IdempotencyKey{ClientId: "checkout", Key: "order-42", RequestHash: strings.Repeat("a", 64)}
+
+		missingId, err := suite.repository.FindIdempotentNotification(idempotencyKey)
+		require.Nilf(t, err, "failed to find notification: %v", err)
+
+		id, _, err := suite.repository.CreateNotificationWithIdempotencyKey(idempotencyKey, &CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		foundId, err := suite.repository.FindIdempotentNotification(idempotencyKey)
+		require.Nilf(t, err, "failed to find notification: %v", err)
+
+		_, err = suite.repository.FindIdempotentNotification(&IdempotencyKey{ClientId: "checkout", Key: "order-42", RequestHash: strings.Repeat("b", 64)})
+
+		assert.Zero(t, missingId)
+		assert.Equal(t, id, foundId)
+		assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+	})
+}
+
 func (suite *PostgresRepositoryTestSuite) TestSuccessFindNotificationStatus() {
 	t := suite.T()
 
@@ -98,8 +276,10 @@ func (suite *PostgresRepositoryTestSuite) TestSuccessFindNotificationStatus() {
 		require.Nilf(t, err, "failed to truncate tables: %v", err)
 
 		createNotification := &CreateNotification{
-			Type:      "email",
-			Recipient: "test@example.com",
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
 		}
 
 		id, err := suite.repository.CreateNotification(createNotification)
@@ -109,7 +289,9 @@ func (suite *PostgresRepositoryTestSuite) TestSuccessFindNotificationStatus() {
 		require.Nilf(t, err, "failed to find notification by ID: %v", err)
 
 		assert.NotNil(t, notificationStatus, "notification should not be nil")
-		assert.False(t, notificationStatus.Sent)
+		assert.Equal(t, StatusScheduled, notificationStatus.Status)
+		assert.NotEmpty(t, notificationStatus.ScheduledAt)
+		assert.Nil(t, notificationStatus.SendingAt)
 		assert.Nil(t, notificationStatus.SentAt)
 	})
 }
@@ -122,8 +304,10 @@ func (suite *PostgresRepositoryTestSuite) TestNotFoundOnFindNotificationStatus()
 		require.Nilf(t, err, "failed to truncate tables: %v", err)
 
 		createNotification := &CreateNotification{
-			Type:      "email",
-			Recipient: "test@example.com",
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
 		}
 
 		id, err := suite.repository.CreateNotification(createNotification)
@@ -144,14 +328,19 @@ func (suite *PostgresRepositoryTestSuite) TestSuccessFindSentNotificationStatus(
 		require.Nilf(t, err, "failed to truncate tables: %v", err)
 
 		createNotification := &CreateNotification{
-			Type:      "email",
-			Recipient: "test@example.com",
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-time.Minute),
 		}
 
 		id, err := suite.repository.CreateNotification(createNotification)
 		require.Nilf(t, err, "failed to create notification: %v", err)
 
-		updated, err := suite.repository.UpdateNotificationAsSent(id)
+		_, err = suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		updated, err := suite.repository.UpdateNotificationAsSent(id, "email")
 		require.Nilf(t, err, "failed to update notification as sent: %v", err)
 
 		notificationStatus, err := suite.repository.FindNotificationStatusByID(id)
@@ -159,8 +348,55 @@ func (suite *PostgresRepositoryTestSuite) TestSuccessFindSentNotificationStatus(
 		require.NotNil(t, notificationStatus, "notification should not be nil")
 
 		assert.True(t, updated)
-		assert.True(t, notificationStatus.Sent)
+		assert.Equal(t, StatusSent, notificationStatus.Status)
+		assert.NotNil(t, notificationStatus.SendingAt)
 		assert.NotNil(t, notificationStatus.SentAt)
+		assert.Equal(t, "email", *notificationStatus.SentChannel)
+		assert.Equal(t, Channels{{Type: "email", Recipient: "test@example.com"}}, notificationStatus.Channels)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestUpdateNotificationForFallback() {
+	t := suite.T()
+
+	t.Run("Should move the notification to the next channel of its chain", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		chain := Channels{
+			{Type: "push", Recipient: "device-token"},
+			{Type: "sms", Recipient: "+5511999999999"},
+		}
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "push",
+			Recipient:   "device-token",
+			Chain:       chain,
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-time.Minute),
+			QuietHours:  &QuietHours{Start: "22:00", End: "08:00", Timezone: "America/Sao_Paulo"},
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		nextAttemptAt := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+		updated, err := suite.repository.UpdateNotificationForFallback(id, 1, chain[1], nextAttemptAt, "provider rejected")
+		require.Nilf(t, err, "failed to fall back: %v", err)
+		assert.True(t, updated)
+
+		notification, err := suite.repository.FindNotificationByID(id)
+		require.Nilf(t, err, "failed to find notification by ID: %v", err)
+
+		assert.Equal(t, StatusQueued, notification.Status)
+		assert.Equal(t, "sms", notification.Type)
+		assert.Equal(t, "+5511999999999", notification.Recipient)
+		assert.Equal(t, 1, notification.ChannelPosition)
+		assert.Equal(t, 0, notification.Attempts)
+		assert.Equal(t, chain, notification.Channels)
+		assert.True(t, nextAttemptAt.Equal(*notification.NextAttemptAt))
+		assert.Equal(t, &QuietHours{Start: "22:00", End: "08:00", Timezone: "America/Sao_Paulo"}, notification.QuietHours)
 	})
 }
 
@@ -172,8 +408,10 @@ func (suite *PostgresRepositoryTestSuite) TestSuccessDeleteNotification() {
 		require.Nilf(t, err, "failed to truncate tables: %v", err)
 
 		createNotification := &CreateNotification{
-			Type:      "email",
-			Recipient: "test@example.com",
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
 		}
 
 		id, err := suite.repository.CreateNotification(createNotification)
@@ -184,7 +422,7 @@ func (suite *PostgresRepositoryTestSuite) TestSuccessDeleteNotification() {
 
 		notification, err := suite.repository.FindNotificationByID(id)
 		require.Nilf(t, err, "failed to find notification by ID: %v", err)
-	
+
 		assert.True(t, deleted)
 		assert.Nil(t, notification)
 	})
@@ -198,8 +436,10 @@ func (suite *PostgresRepositoryTestSuite) TestNotFoundOnDeleteNotification() {
 		require.Nilf(t, err, "failed to truncate tables: %v", err)
 
 		createNotification := &CreateNotification{
-			Type:      "email",
-			Recipient: "test@example.com",
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
 		}
 
 		id, err := suite.repository.CreateNotification(createNotification)
@@ -215,3 +455,690 @@ func (suite *PostgresRepositoryTestSuite) TestNotFoundOnDeleteNotification() {
 		assert.NotNil(t, notification)
 	})
 }
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessClaimDueNotifications() {
+	t := suite.T()
+
+	t.Run("Should claim only due notifications", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		dueId, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-time.Minute),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		claimed, err := suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		claimedAgain, err := suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		require.Len(t, claimed, 1)
+		assert.Equal(t, dueId, claimed[0].Id)
+		assert.Empty(t, claimedAgain)
+	})
+
+	t.Run("Should not claim notifications delayed to their send window", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		sendWindow := &SendWindow{Start: "08:00", End: "21:00", Weekdays: []string{"mon"}}
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:             "email",
+			Recipient:        "test@example.com",
+			Message:          "Hello",
+			ScheduledAt:      time.Now().Add(-time.Minute),
+			SendWindow:       sendWindow,
+			Timezone:         "America/Sao_Paulo",
+			DeliverNotBefore: time.Now().Add(time.Hour),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		claimed, err := suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		notification, err := suite.repository.FindNotificationByID(id)
+		require.Nilf(t, err, "failed to find notification by ID: %v", err)
+
+		assert.Empty(t, claimed)
+		assert.Equal(t, sendWindow, notification.SendWindow)
+		assert.Equal(t, "America/Sao_Paulo", *notification.Timezone)
+		assert.True(t, notification.DeliverNotBefore.After(notification.ScheduledAt))
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestPriorityOnClaimDueNotifications() {
+	t := suite.T()
+
+	t.Run("Should claim the most urgent due notifications first", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		lowId, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			Priority:    "low",
+			ScheduledAt: time.Now().Add(-time.Hour),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		normalId, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-2 * time.Minute),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		criticalId, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "sms",
+			Recipient:   "+5511999999999",
+			Message:     "Your code is 123456",
+			Priority:    "critical",
+			ScheduledAt: time.Now().Add(-time.Minute),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		claimed, err := suite.repository.ClaimDueNotifications(2, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		require.Len(t, claimed, 2)
+		assert.Equal(t, criticalId, claimed[0].Id)
+		assert.Equal(t, "critical", claimed[0].Priority)
+		assert.Equal(t, normalId, claimed[1].Id)
+		assert.Equal(t, "normal", claimed[1].Priority)
+		assert.NotContains(t, []int64{claimed[0].Id, claimed[1].Id}, lowId)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessFindRecipientDeliveries() {
+	t := suite.T()
+
+	t.Run("Should find the pending and sent deliveries of the recipient", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		scheduledAt := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+		createNotification := func(notificationType, recipient string, scheduledAt time.Time) int64 {
+			id, err := suite.repository.CreateNotification(&CreateNotification{
+				Type:        notificationType,
+				Recipient:   recipient,
+				Message:     "Hello",
+				ScheduledAt: scheduledAt,
+			})
+			require.Nilf(t, err, "failed to create notification: %v", err)
+
+			return id
+		}
+
+		createNotification("sms", "+5511999999999", scheduledAt)
+		createNotification("sms", "+5511999999999", scheduledAt.Add(-48*time.Hour))
+		createNotification("sms", "+5511888888888", scheduledAt)
+		createNotification("email", "test@example.com", scheduledAt)
+		excludedId := createNotification("sms", "+5511999999999", scheduledAt.Add(time.Hour))
+		canceledId := createNotification("sms", "+5511999999999", scheduledAt.Add(2*time.Hour))
+
+		_, err = suite.repository.CancelNotificationByID(canceledId, "")
+		require.Nilf(t, err, "failed to cancel notification: %v", err)
+
+		deliveries, err := suite.repository.FindRecipientDeliveries("sms", "+5511999999999", scheduledAt.Add(-24*time.Hour), excludedId)
+		require.Nilf(t, err, "failed to find deliveries: %v", err)
+
+		require.Len(t, deliveries, 1)
+		assert.True(t, scheduledAt.Equal(deliveries[0]))
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessFindRecipientsDeliveries() {
+	t := suite.T()
+
+	t.Run("Should find the deliveries of each recipient after its own since", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		scheduledAt := time.Now().Add(time.Hour).Truncate(time.Microsecond)
+		createNotification := func(notificationType, recipient string, scheduledAt time.Time) {
+			_, err := suite.repository.CreateNotification(&CreateNotification{
+				Type:        notificationType,
+				Recipient:   recipient,
+				Message:     "Hello",
+				ScheduledAt: scheduledAt,
+			})
+			require.Nilf(t, err, "failed to create notification: %v", err)
+		}
+
+		createNotification("sms", "+5511999999999", scheduledAt)
+		createNotification("sms", "+5511999999999", scheduledAt.Add(-48*time.Hour))
+		createNotification("email", "test@example.com", scheduledAt)
+		createNotification("email", "test@example.com", scheduledAt.Add(-2*time.Hour))
+		createNotification("sms", "+5511888888888", scheduledAt)
+
+		sms := RecipientKey{Type: "sms", Recipient: "+5511999999999"}
+		email := RecipientKey{Type: "email", Recipient: "test@example.com"}
+		push := RecipientKey{Type: "push", Recipient: "device-token"}
+
+		deliveries, err := suite.repository.FindRecipientsDeliveries(map[RecipientKey]time.Time{
+			sms:   scheduledAt.Add(-24 * time.Hour),
+			email: scheduledAt.Add(-3 * time.Hour),
+			push:  scheduledAt.Add(-24 * time.Hour),
+		})
+		require.Nilf(t, err, "failed to find deliveries: %v", err)
+
+		require.Len(t, deliveries, 3)
+		require.Len(t, deliveries[sms], 1)
+		assert.True(t, scheduledAt.Equal(deliveries[sms][0]))
+		require.Len(t, deliveries[email], 2)
+		assert.True(t, scheduledAt.Add(-2*time.Hour).Equal(deliveries[email][0]))
+		assert.True(t, scheduledAt.Equal(deliveries[email][1]))
+		assert.Empty(t, deliveries[push])
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessReleaseNotification() {
+	t := suite.T()
+
+	t.Run("Should claim released notification again", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-time.Minute),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		released, err := suite.repository.ReleaseNotification(id)
+		require.Nilf(t, err, "failed to release notification: %v", err)
+
+		claimed, err := suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		assert.True(t, released)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, id, claimed[0].Id)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessExpireNotifications() {
+	t := suite.T()
+
+	t.Run("Should expire notifications not sent before their expiry instead of claiming them", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		expiredAt := time.Now().Add(-time.Second)
+		expiresAt := time.Now().Add(time.Hour)
+
+		expiredId, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "sms",
+			Recipient:   "+5511999999999",
+			Message:     "Your code is 123456",
+			ScheduledAt: time.Now().Add(-time.Minute),
+			ExpiresAt:   &expiredAt,
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		dueId, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "sms",
+			Recipient:   "+5511999999999",
+			Message:     "Your code is 654321",
+			ScheduledAt: time.Now().Add(-time.Minute),
+			ExpiresAt:   &expiresAt,
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		expired, err := suite.repository.ExpireNotifications(time.Minute)
+		require.Nilf(t, err, "failed to expire notifications: %v", err)
+
+		claimed, err := suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		status, err := suite.repository.FindNotificationStatusByID(expiredId)
+		require.Nilf(t, err, "failed to find notification status: %v", err)
+
+		assert.Equal(t, int64(1), expired)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, dueId, claimed[0].Id)
+		assert.True(t, expiresAt.Truncate(time.Microsecond).Equal(*claimed[0].ExpiresAt))
+		assert.Equal(t, StatusExpired, status.Status)
+		assert.NotNil(t, status.ExpiresAt)
+		assert.NotNil(t, status.ExpiredAt)
+	})
+
+	t.Run("Should expire a claimed notification", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		expiresAt := time.Now().Add(time.Hour)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "sms",
+			Recipient:   "+5511999999999",
+			Message:     "Your code is 123456",
+			ScheduledAt: time.Now().Add(-time.Minute),
+			ExpiresAt:   &expiresAt,
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		updated, err := suite.repository.UpdateNotificationAsExpired(id)
+		require.Nilf(t, err, "failed to expire notification: %v", err)
+
+		notification, err := suite.repository.FindNotificationByID(id)
+		require.Nilf(t, err, "failed to find notification by ID: %v", err)
+
+		assert.True(t, updated)
+		assert.Equal(t, StatusExpired, notification.Status)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestInvalidTransitionOnUpdateNotificationAsSent() {
+	t := suite.T()
+
+	t.Run("Should not mark a notification that is not sending as sent", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		updated, err := suite.repository.UpdateNotificationAsSent(id, "email")
+
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+		assert.False(t, updated)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessUpdateNotificationAsFailed() {
+	t := suite.T()
+
+	t.Run("Should mark sending notification as failed", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-time.Minute),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		updated, err := suite.repository.UpdateNotificationAsFailed(id, "provider unavailable")
+		require.Nilf(t, err, "failed to update notification as failed: %v", err)
+
+		notificationStatus, err := suite.repository.FindNotificationStatusByID(id)
+		require.Nilf(t, err, "failed to find notification by ID: %v", err)
+		require.NotNil(t, notificationStatus, "notification should not be nil")
+
+		assert.True(t, updated)
+		assert.Equal(t, StatusFailed, notificationStatus.Status)
+		assert.NotNil(t, notificationStatus.FailedAt)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessCancelNotification() {
+	t := suite.T()
+
+	t.Run("Should cancel scheduled notification keeping it stored", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		canceled, err := suite.repository.CancelNotificationByID(id, "customer request")
+		require.Nilf(t, err, "failed to cancel notification: %v", err)
+
+		notificationStatus, err := suite.repository.FindNotificationStatusByID(id)
+		require.Nilf(t, err, "failed to find notification by ID: %v", err)
+		require.NotNil(t, notificationStatus, "notification should not be nil")
+
+		assert.True(t, canceled)
+		assert.Equal(t, StatusCanceled, notificationStatus.Status)
+		assert.NotNil(t, notificationStatus.CanceledAt)
+		require.NotNil(t, notificationStatus.CancelReason)
+		assert.Equal(t, "customer request", *notificationStatus.CancelReason)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestInvalidTransitionOnCancelNotification() {
+	t := suite.T()
+
+	t.Run("Should not cancel notification that is being sent", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-time.Minute),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		canceled, err := suite.repository.CancelNotificationByID(id, "")
+
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+		assert.False(t, canceled)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessUpdateNotification() {
+	t := suite.T()
+
+	t.Run("Should reschedule and edit pending notification", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		message := "Hello again"
+		scheduledAt := time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
+
+		updated, err := suite.repository.UpdateNotificationByID(id, &UpdateNotification{
+			Message:     &message,
+			ScheduledAt: &scheduledAt,
+		})
+		require.Nilf(t, err, "failed to update notification: %v", err)
+
+		notification, err := suite.repository.FindNotificationByID(id)
+		require.Nilf(t, err, "failed to find notification by ID: %v", err)
+		require.NotNil(t, notification, "notification should not be nil")
+
+		assert.True(t, updated)
+		assert.Equal(t, "test@example.com", notification.Recipient)
+		assert.Equal(t, message, notification.Message)
+		assert.True(t, scheduledAt.Equal(notification.ScheduledAt))
+
+		events, err := suite.repository.FindNotificationEventsByID(id)
+		require.Nilf(t, err, "failed to find notification events: %v", err)
+		require.Len(t, events, 2)
+
+		assert.Equal(t, EventUpdated, events[1].Event)
+		assert.Equal(t, StatusScheduled, events[1].Status)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestNotPendingOnUpdateNotification() {
+	t := suite.T()
+
+	t.Run("Should not update notification that is being sent", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-time.Minute),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		message := "Hello again"
+		updated, err := suite.repository.UpdateNotificationByID(id, &UpdateNotification{Message: &message})
+
+		assert.ErrorIs(t, err, ErrNotificationNotPending)
+		assert.False(t, updated)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessFindNotificationEvents() {
+	t := suite.T()
+
+	t.Run("Should record every status change on the notification timeline", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-time.Minute),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		_, err = suite.repository.UpdateNotificationAsFailed(id, "provider unavailable")
+		require.Nilf(t, err, "failed to update notification as failed: %v", err)
+
+		events, err := suite.repository.FindNotificationEventsByID(id)
+		require.Nilf(t, err, "failed to find notification events: %v", err)
+
+		require.Len(t, events, 3)
+		assert.Equal(t, EventCreated, events[0].Event)
+		assert.Equal(t, ActorAPI, events[0].Actor)
+		assert.Equal(t, StatusSending, events[1].Status)
+		assert.Equal(t, ActorDispatcher, events[1].Actor)
+		assert.Equal(t, StatusFailed, events[2].Status)
+		require.NotNil(t, events[2].Error)
+		assert.Equal(t, "provider unavailable", *events[2].Error)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestNotFoundOnFindNotificationEvents() {
+	t := suite.T()
+
+	t.Run("Should not find events of a missing notification", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		events, err := suite.repository.FindNotificationEventsByID(1)
+		require.Nilf(t, err, "failed to find notification events: %v", err)
+
+		assert.Nil(t, events)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessUpdateNotificationForRetry() {
+	t := suite.T()
+
+	t.Run("Should re-queue failed attempt and only claim it again after next attempt time", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-time.Minute),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		updated, err := suite.repository.UpdateNotificationForRetry(id, time.Now().Add(time.Hour), "provider unavailable")
+		require.Nilf(t, err, "failed to update notification for retry: %v", err)
+
+		claimed, err := suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		notification, err := suite.repository.FindNotificationByID(id)
+		require.Nilf(t, err, "failed to find notification by ID: %v", err)
+		require.NotNil(t, notification, "notification should not be nil")
+
+		events, err := suite.repository.FindNotificationEventsByID(id)
+		require.Nilf(t, err, "failed to find notification events: %v", err)
+
+		assert.True(t, updated)
+		assert.Empty(t, claimed)
+		assert.Equal(t, StatusQueued, notification.Status)
+		assert.Equal(t, 1, notification.Attempts)
+		assert.NotNil(t, notification.NextAttemptAt)
+		require.NotNil(t, notification.LastError)
+		assert.Equal(t, "provider unavailable", *notification.LastError)
+		require.Len(t, events, 3)
+		assert.Equal(t, EventAttemptFailed, events[2].Event)
+		require.NotNil(t, events[2].Attempt)
+		assert.Equal(t, 1, *events[2].Attempt)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessRetryNotification() {
+	t := suite.T()
+
+	t.Run("Should re-queue failed notification with a fresh set of attempts", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(-time.Minute),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		_, err = suite.repository.UpdateNotificationAsFailed(id, "provider unavailable")
+		require.Nilf(t, err, "failed to update notification as failed: %v", err)
+
+		retried, err := suite.repository.RetryNotificationByID(id, time.Now())
+		require.Nilf(t, err, "failed to retry notification: %v", err)
+
+		claimed, err := suite.repository.ClaimDueNotifications(10, time.Minute)
+		require.Nilf(t, err, "failed to claim notifications: %v", err)
+
+		assert.True(t, retried)
+		require.Len(t, claimed, 1)
+		assert.Equal(t, id, claimed[0].Id)
+		assert.Zero(t, claimed[0].Attempts)
+	})
+
+	t.Run("Should not retry notification that has not failed", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "email",
+			Recipient:   "test@example.com",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		retried, err := suite.repository.RetryNotificationByID(id, time.Now())
+
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+		assert.False(t, retried)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessListNotifications() {
+	t := suite.T()
+
+	t.Run("Should filter and paginate notifications from newest to oldest", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		ids := make([]int64, 0)
+
+		for _, recipient := range []string{"test@example.com", "other@example.com", "test@example.com", "test@example.com"} {
+			id, err := suite.repository.CreateNotification(&CreateNotification{
+				Type:        "email",
+				Recipient:   recipient,
+				Message:     "Hello",
+				ScheduledAt: time.Now().Add(time.Hour),
+			})
+			require.Nilf(t, err, "failed to create notification: %v", err)
+
+			ids = append(ids, id)
+		}
+
+		firstPage, err := suite.repository.ListNotifications(&ListNotificationsFilter{Recipient: "test@example.com", Limit: 2})
+		require.Nilf(t, err, "failed to list notifications: %v", err)
+		require.NotNil(t, firstPage.NextCursor)
+
+		secondPage, err := suite.repository.ListNotifications(&ListNotificationsFilter{Recipient: "test@example.com", Cursor: *firstPage.NextCursor, Limit: 2})
+		require.Nilf(t, err, "failed to list notifications: %v", err)
+
+		require.Len(t, firstPage.Data, 2)
+		assert.Equal(t, ids[3], firstPage.Data[0].Id)
+		assert.Equal(t, ids[2], firstPage.Data[1].Id)
+		require.Len(t, secondPage.Data, 1)
+		assert.Equal(t, ids[0], secondPage.Data[0].Id)
+		assert.Nil(t, secondPage.NextCursor)
+	})
+
+	t.Run("Should filter notifications by status and schedule range", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateNotification(&CreateNotification{
+			Type:        "sms",
+			Recipient:   "1234567890",
+			Message:     "Hello",
+			ScheduledAt: time.Now().Add(time.Hour),
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		_, err = suite.repository.CancelNotificationByID(id, "")
+		require.Nilf(t, err, "failed to cancel notification: %v", err)
+
+		canceled, err := suite.repository.ListNotifications(&ListNotificationsFilter{
+			Status:        string(StatusCanceled),
+			ScheduledFrom: time.Now(),
+			ScheduledTo:   time.Now().Add(2 * time.Hour),
+			Limit:         10,
+		})
+		require.Nilf(t, err, "failed to list notifications: %v", err)
+
+		scheduled, err := suite.repository.ListNotifications(&ListNotificationsFilter{Status: string(StatusScheduled), Limit: 10})
+		require.Nilf(t, err, "failed to list notifications: %v", err)
+
+		require.Len(t, canceled.Data, 1)
+		assert.Equal(t, id, canceled.Data[0].Id)
+		assert.Empty(t, scheduled.Data)
+	})
+}
@@ -0,0 +1,59 @@
+package notifications
+
+import "errors"
+
+type Status string
+
+const (
+	StatusScheduled Status = "scheduled"
+	StatusQueued    Status = "queued"
+	StatusSending   Status = "sending"
+	StatusSent      Status = "sent"
+	StatusFailed    Status = "failed"
+	StatusCanceled  Status = "canceled"
+	StatusExpired   Status = "expired"
+)
+
+var Statuses = []string{
+	string(StatusScheduled),
+	string(StatusQueued),
+	string(StatusSending),
+	string(StatusSent),
+	string(StatusFailed),
+	string(StatusCanceled),
+	string(StatusExpired),
+}
+
+var ErrInvalidStatusTransition = errors.New("invalid notification status transition")
+var ErrNotificationNotPending = errors.New("notification is no longer pending")
+
+var statusTransitions = map[Status][]Status{
+	StatusScheduled: {StatusSending, StatusCanceled, StatusExpired},
+	StatusQueued:    {StatusSending, StatusCanceled, StatusExpired},
+	StatusSending:   {StatusQueued, StatusSent, StatusFailed, StatusExpired},
+	StatusFailed:    {StatusQueued, StatusCanceled},
+}
+
+var statusTimestampColumns = map[Status]string{
+	StatusQueued:   "queued_at",
+	StatusSending:  "sending_at",
+	StatusSent:     "sent_at",
+	StatusFailed:   "failed_at",
+	StatusCanceled: "canceled_at",
+	StatusExpired:  "expired_at",
+}
+
+// IsPending reports whether the notification has not been picked up for sending yet.
+func (s Status) IsPending() bool {
+	return s == StatusScheduled || s == StatusQueued
+}
+
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+package notifications
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStatusTransitions(t *testing.T) {
+	testCases := []struct {
+		from     Status
+		to       Status
+		expected bool
+	}{
+		{StatusScheduled, StatusSending, true},
+		{StatusScheduled, StatusCanceled, true},
+		{StatusScheduled, StatusSent, false},
+		{StatusScheduled, StatusQueued, false},
+		{StatusQueued, StatusSending, true},
+		{StatusSending, StatusSent, true},
+		{StatusSending, StatusFailed, true},
+		{StatusSending, StatusCanceled, false},
+		{StatusSending, StatusExpired, true},
+		{StatusFailed, StatusQueued, true},
+		{StatusSent, StatusCanceled, false},
+		{StatusCanceled, StatusQueued, false},
+		{StatusExpired, StatusSending, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run("Should check transition from "+string(tc.from)+" to "+string(tc.to), func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.from.CanTransitionTo(tc.to))
+		})
+	}
+}
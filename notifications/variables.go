@@ -0,0 +1,32 @@
+package notifications
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Variables holds the values a template is rendered with, stored as JSONB.
+type Variables map[string]any
+
+func (v Variables) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(v)
+}
+
+func (v *Variables) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(src, v)
+	case string:
+		return json.Unmarshal([]byte(src), v)
+	}
+
+	return fmt.Errorf("cannot scan %T into Variables", src)
+}
@@ -0,0 +1,212 @@
+package notifications
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+var ClockRegex = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+var Weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// MaxWindowShifts bounds how many times the send window and the quiet hours
+// may push each other forward before they are considered disjoint.
+const MaxWindowShifts = 16
+
+// SendWindow is the daily period, from Start to End in local time, in which a
+// notification may be delivered, optionally restricted to some weekdays. Start
+// after End spans midnight, the period belonging to the weekday it starts on.
+type SendWindow struct {
+	Start    string   `json:"start"`
+	End      string   `json:"end"`
+	Weekdays []string `json:"weekdays"`
+}
+
+func (w *SendWindow) Value() (driver.Value, error) {
+	if w == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(w)
+}
+
+func (w *SendWindow) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, w)
+	case string:
+		return json.Unmarshal([]byte(src), w)
+	}
+
+	return fmt.Errorf("cannot scan %T into SendWindow", src)
+}
+
+// Allows reports whether t falls inside the window in location.
+func (w *SendWindow) Allows(t time.Time, location *time.Location) bool {
+	if w == nil {
+		return true
+	}
+
+	local := t.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	start, end := clockMinutes(w.Start), clockMinutes(w.End)
+	weekday := local.Weekday()
+
+	if start < end {
+		return minute >= start && minute < end && w.allowsWeekday(weekday)
+	}
+
+	if minute >= start {
+		return w.allowsWeekday(weekday)
+	}
+
+	return minute < end && w.allowsWeekday((weekday+6)%7)
+}
+
+// Next returns t when the window allows it, otherwise the next time the window
+// opens.
+func (w *SendWindow) Next(t time.Time, location *time.Location) time.Time {
+	if w.Allows(t, location) {
+		return t
+	}
+
+	local := t.In(location)
+	start := clockMinutes(w.Start)
+
+	for days := 0; days <= 7; days++ {
+		day := local.AddDate(0, 0, days)
+		opening := time.Date(day.Year(), day.Month(), day.Day(), start/60, start%60, 0, 0, location)
+
+		if opening.After(t) && w.Allows(opening, location) {
+			return opening
+		}
+	}
+
+	return t
+}
+
+// QuietHours is the daily period, from Start to End in Timezone, in which the
+// recipient does not want to be disturbed. Start after End spans midnight.
+type QuietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone"`
+}
+
+func (q *QuietHours) Value() (driver.Value, error) {
+	if q == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(q)
+}
+
+func (q *QuietHours) Scan(src any) error {
+	switch src := src.(type) {
+	case []byte:
+		return json.Unmarshal(src, q)
+	case string:
+		return json.Unmarshal([]byte(src), q)
+	}
+
+	return fmt.Errorf("cannot scan %T into QuietHours", src)
+}
+
+// SendWindow returns the period outside the quiet hours, in which the recipient
+// may be notified.
+func (q *QuietHours) SendWindow() *SendWindow {
+	return &SendWindow{Start: q.End, End: q.Start}
+}
+
+func (q *QuietHours) Location() *time.Location {
+	location, err := time.LoadLocation(q.Timezone)
+
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+type zonedWindow struct {
+	window   *SendWindow
+	location *time.Location
+}
+
+// NextSendTime returns the first time from t inside the send window, in the
+// timezone, and outside the quiet hours. It reports false when the two never
+// overlap.
+func NextSendTime(t time.Time, sendWindow *SendWindow, timezone string, quietHours *QuietHours) (time.Time, bool) {
+	location, err := time.LoadLocation(timezone)
+
+	if err != nil {
+		location = time.UTC
+	}
+
+	windows := []zonedWindow{{window: sendWindow, location: location}}
+
+	if quietHours != nil {
+		windows = append(windows, zonedWindow{window: quietHours.SendWindow(), location: quietHours.Location()})
+	}
+
+	next := t
+
+	for range MaxWindowShifts {
+		shifted := next
+
+		for _, w := range windows {
+			shifted = w.window.Next(shifted, w.location)
+		}
+
+		if shifted.Equal(next) {
+			return next, true
+		}
+
+		next = shifted
+	}
+
+	return next, false
+}
+
+// NextSendTime returns the first time from t the notification may be delivered,
+// inside its send window and outside the quiet hours of its contact. When they
+// never overlap, which creation rejects, t is returned unchanged.
+func (n *Notification) NextSendTime(t time.Time) time.Time {
+	var timezone string
+
+	if n.Timezone != nil {
+		timezone = *n.Timezone
+	}
+
+	next, ok := NextSendTime(t, n.SendWindow, timezone, n.QuietHours)
+
+	if !ok {
+		return t
+	}
+
+	return next
+}
+
+func (w *SendWindow) allowsWeekday(weekday time.Weekday) bool {
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+
+	for _, allowed := range w.Weekdays {
+		if allowed == Weekdays[weekday] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func clockMinutes(clock string) int {
+	var hour, minute int
+	fmt.Sscanf(clock, "%d:%d", &hour, &minute)
+
+	return hour*60 + minute
+}
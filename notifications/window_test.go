@@ -0,0 +1,84 @@
+package notifications
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSendWindowNext(t *testing.T) {
+	location, err := time.LoadLocation("America/Sao_Paulo")
+
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2030, time.January, day, hour, minute, 0, 0, location)
+	}
+
+	businessHours := &SendWindow{Start: "08:00", End: "21:00", Weekdays: []string{"mon", "tue", "wed", "thu", "fri"}}
+	overnight := &SendWindow{Start: "22:00", End: "02:00", Weekdays: []string{"fri"}}
+
+	testCases := []struct {
+		name     string
+		window   *SendWindow
+		t        time.Time
+		expected time.Time
+	}{
+		{"Should keep a time inside the window", businessHours, at(7, 10, 30), at(7, 10, 30)},
+		{"Should move a time before the window to its start", businessHours, at(7, 6, 0), at(7, 8, 0)},
+		{"Should move a time after the window to the next day", businessHours, at(7, 21, 0), at(8, 8, 0)},
+		{"Should skip the weekdays not allowed", businessHours, at(11, 22, 0), at(14, 8, 0)},
+		{"Should keep a time after midnight of a window spanning it", overnight, at(12, 1, 0), at(12, 1, 0)},
+		{"Should move to the start of a window spanning midnight", overnight, at(12, 3, 0), at(18, 22, 0)},
+		{"Should keep any time without a window", nil, at(12, 3, 0), at(12, 3, 0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.True(t, tc.expected.Equal(tc.window.Next(tc.t, location)), "expected %v, got %v", tc.expected, tc.window.Next(tc.t, location))
+		})
+	}
+}
+
+func TestQuietHoursSendWindow(t *testing.T) {
+	t.Run("Should allow sending from the end to the start of the quiet hours", func(t *testing.T) {
+		quietHours := &QuietHours{Start: "22:00", End: "08:00", Timezone: "America/Sao_Paulo"}
+
+		assert.Equal(t, &SendWindow{Start: "08:00", End: "22:00"}, quietHours.SendWindow())
+	})
+}
+
+func TestNextSendTime(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2030, time.January, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	quietHours := &QuietHours{Start: "22:00", End: "08:00", Timezone: "America/Sao_Paulo"}
+
+	testCases := []struct {
+		name       string
+		sendWindow *SendWindow
+		t          time.Time
+		expected   time.Time
+		expectedOk bool
+	}{
+		{"Should keep a time outside the quiet hours", nil, at(7, 15, 0), at(7, 15, 0), true},
+		{"Should move a time inside the quiet hours to their end", nil, at(7, 2, 0), at(7, 11, 0), true},
+		{"Should move to a send window opening outside the quiet hours", &SendWindow{Start: "07:00", End: "12:00"}, at(7, 2, 0), at(7, 11, 0), true},
+		{"Should fail when the send window is inside the quiet hours", &SendWindow{Start: "23:00", End: "23:30"}, at(7, 2, 0), time.Time{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, ok := NextSendTime(tc.t, tc.sendWindow, "America/Sao_Paulo", quietHours)
+
+			assert.Equal(t, tc.expectedOk, ok)
+
+			if tc.expectedOk {
+				assert.True(t, tc.expected.Equal(next), "expected %v, got %v", tc.expected, next)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package recurring
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultPollInterval = time.Minute
+	defaultHorizon      = 24 * time.Hour
+	defaultBatchSize    = 100
+	defaultClaimLease   = 5 * time.Minute
+)
+
+type Config struct {
+	PollInterval time.Duration
+	Horizon      time.Duration
+	BatchSize    int
+	ClaimLease   time.Duration
+}
+
+func ConfigFromEnv() (*Config, error) {
+	config := &Config{
+		PollInterval: defaultPollInterval,
+		Horizon:      defaultHorizon,
+		BatchSize:    defaultBatchSize,
+		ClaimLease:   defaultClaimLease,
+	}
+
+	if value := os.Getenv("RECURRING_POLL_INTERVAL"); value != "" {
+		pollInterval, err := time.ParseDuration(value)
+
+		if err != nil || pollInterval <= 0 {
+			return nil, fmt.Errorf("invalid RECURRING_POLL_INTERVAL %q", value)
+		}
+
+		config.PollInterval = pollInterval
+	}
+
+	if value := os.Getenv("RECURRING_HORIZON"); value != "" {
+		horizon, err := time.ParseDuration(value)
+
+		if err != nil || horizon <= 0 {
+			return nil, fmt.Errorf("invalid RECURRING_HORIZON %q", value)
+		}
+
+		config.Horizon = horizon
+	}
+
+	if value := os.Getenv("RECURRING_BATCH_SIZE"); value != "" {
+		batchSize, err := strconv.Atoi(value)
+
+		if err != nil || batchSize <= 0 {
+			return nil, fmt.Errorf("invalid RECURRING_BATCH_SIZE %q", value)
+		}
+
+		config.BatchSize = batchSize
+	}
+
+	if value := os.Getenv("RECURRING_CLAIM_LEASE"); value != "" {
+		claimLease, err := time.ParseDuration(value)
+
+		if err != nil || claimLease <= 0 {
+			return nil, fmt.Errorf("invalid RECURRING_CLAIM_LEASE %q", value)
+		}
+
+		config.ClaimLease = claimLease
+	}
+
+	return config, nil
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"net/http"
+	"time"
+)
+
+var createRecurringNotificationSchema = zog.Struct(zog.Schema{
+	"rrule":    zog.String().Trim().Max(1024),
+	"cron":     zog.String().Trim().Max(255),
+	"timezone": zog.String().Trim().TestFunc(httputil.IsTimezone, zog.Message("must be an IANA time zone such as America/Sao_Paulo")),
+	"startsAt": zog.Time(),
+	"endsAt":   zog.Ptr(zog.Time().TestFunc(httputil.IsInTheFuture, zog.Message("must be in the future"))),
+})
+
+var errInvalidBody = errors.New("invalid request body")
+var errNotificationRequired = errors.New(`The field "notification" is required`)
+var errInvalidNotification = errors.New(`The field "notification" must be a notification object`)
+var errExpiresAtOnRecurring = errors.New(`The field "notification.expires_at" is not supported on recurring notifications`)
+var errRruleOrCron = errors.New(`Exactly one of the fields "rrule" or "cron" must be informed`)
+var errEndsBeforeStart = errors.New(`The field "ends_at" must be after "starts_at"`)
+var errNoOccurrence = errors.New(`The schedule has no occurrence in the future`)
+
+type CreateHandler struct {
+	recurringRepository recurring.Repository
+	preparer            *prepare.Preparer
+}
+
+func NewCreateHandler(recurringRepository recurring.Repository, preparer *prepare.Preparer) *CreateHandler {
+	return &CreateHandler{
+		recurringRepository: recurringRepository,
+		preparer:            preparer,
+	}
+}
+
+func (h *CreateHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var createRecurringNotification *recurring.CreateRecurringNotification
+	err := json.NewDecoder(r.Body).Decode(&createRecurringNotification)
+
+	if err != nil || createRecurringNotification == nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
+	validationErrors := createRecurringNotificationSchema.Validate(createRecurringNotification)
+
+	if validationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(validationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	now := time.Now()
+	first, unprocessableEntityError := validateSchedule(createRecurringNotification, now)
+
+	if unprocessableEntityError != nil {
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	unprocessableEntityError, err = h.validateNotification(createRecurringNotification, first)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if unprocessableEntityError != nil {
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	createRecurringNotification.MaterializedUntil = now
+
+	id, err := h.recurringRepository.CreateRecurringNotification(createRecurringNotification)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	recurringNotification, err := h.recurringRepository.FindRecurringNotificationByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.CreatedResponse(w, recurringNotification)
+}
+
+// validateSchedule fills the defaults of the schedule, checking that it parses,
+// and returns its first occurrence after now.
+func validateSchedule(createRecurringNotification *recurring.CreateRecurringNotification, now time.Time) (time.Time, *httputil.UnprocessableEntityError) {
+	if createRecurringNotification.Timezone == "" {
+		createRecurringNotification.Timezone = "UTC"
+	}
+
+	if createRecurringNotification.StartsAt.IsZero() {
+		createRecurringNotification.StartsAt = now
+	}
+
+	if createRecurringNotification.EndsAt != nil && !createRecurringNotification.EndsAt.After(createRecurringNotification.StartsAt) {
+		return time.Time{}, &httputil.UnprocessableEntityError{Errors: []string{errEndsBeforeStart.Error()}}
+	}
+
+	schedule, err := recurring.ParseSchedule(
+		createRecurringNotification.Rrule,
+		createRecurringNotification.Cron,
+		createRecurringNotification.Timezone,
+		createRecurringNotification.StartsAt,
+		createRecurringNotification.EndsAt,
+	)
+
+	if errors.Is(err, recurring.ErrRruleOrCron) {
+		return time.Time{}, &httputil.UnprocessableEntityError{Errors: []string{errRruleOrCron.Error()}}
+	}
+
+	if err != nil {
+		field := "rrule"
+
+		if createRecurringNotification.Cron != "" {
+			field = "cron"
+		}
+
+		return time.Time{}, &httputil.UnprocessableEntityError{Errors: []string{`The field "` + field + `" is invalid: ` + err.Error()}}
+	}
+
+	first := schedule.Next(now)
+
+	if first.IsZero() {
+		return first, &httputil.UnprocessableEntityError{Errors: []string{errNoOccurrence.Error()}}
+	}
+
+	return first, nil
+}
+
+// validateNotification checks the notification of the series as it would be
+// created for its first occurrence. Frequency caps are left to each occurrence,
+// as it is materialized.
+func (h *CreateHandler) validateNotification(createRecurringNotification *recurring.CreateRecurringNotification, first time.Time) (*httputil.UnprocessableEntityError, error) {
+	if len(createRecurringNotification.Notification) == 0 || string(createRecurringNotification.Notification) == "null" {
+		return &httputil.UnprocessableEntityError{Errors: []string{errNotificationRequired.Error()}}, nil
+	}
+
+	occurrence, err := recurring.NewOccurrence(createRecurringNotification.Notification, first)
+
+	if err != nil {
+		return &httputil.UnprocessableEntityError{Errors: []string{errInvalidNotification.Error()}}, nil
+	}
+
+	// An absolute expiration would be copied into every occurrence and all but
+	// the first ones would expire before being scheduled.
+	if occurrence.ExpiresAt != nil {
+		return &httputil.UnprocessableEntityError{Errors: []string{errExpiresAtOnRecurring.Error()}}, nil
+	}
+
+	errs, err := h.preparer.Validate([]*notifications.CreateNotification{occurrence})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if validationError, ok := errs[0]; ok {
+		return &httputil.UnprocessableEntityError{Errors: validationError.Errors}, nil
+	}
+
+	return nil, nil
+}
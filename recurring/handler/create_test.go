@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"encoding/json"
+	contactMocks "github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	notificationMocks "github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"github.com/Tagliatti/magalu-challenge/recurring/mocks"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	suppressionMocks "github.com/Tagliatti/magalu-challenge/suppressions/mocks"
+	templateMocks "github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newPreparer(t *testing.T) *prepare.Preparer {
+	suppressionRepository := suppressionMocks.NewRepository(t)
+	suppressionRepository.On("FindSuppressed", mock.Anything).Return(map[suppressions.Key]string{}, nil).Maybe()
+
+	return prepare.NewPreparer(notificationMocks.NewRepository(t), templateMocks.NewRepository(t), suppressionRepository, contactMocks.NewRepository(t), nil)
+}
+
+func TestSuccessCreate(t *testing.T) {
+	t.Run("Should create a recurring notification successfully", func(t *testing.T) {
+		body := `{"notification":{"type":"sms","recipient":"+5511999999999","message":"Hello"},"cron":"0 9 * * MON","timezone":"America/Sao_Paulo"}`
+		cron := "0 9 * * MON"
+
+		recurringNotification := recurring.RecurringNotification{
+			Id:           1,
+			Notification: json.RawMessage(`{"type":"sms","recipient":"+5511999999999","message":"Hello"}`),
+			Cron:         &cron,
+			Timezone:     "America/Sao_Paulo",
+			Status:       recurring.StatusActive,
+			CreatedAt:    time.Now().UTC(),
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/recurring-notifications", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateRecurringNotification", mock.MatchedBy(func(createRecurringNotification *recurring.CreateRecurringNotification) bool {
+			return createRecurringNotification.Cron == cron &&
+				createRecurringNotification.Timezone == "America/Sao_Paulo" &&
+				!createRecurringNotification.StartsAt.IsZero() &&
+				!createRecurringNotification.MaterializedUntil.IsZero()
+		})).Return(int64(1), nil)
+		repository.On("FindRecurringNotificationByID", int64(1)).Return(&recurringNotification, nil)
+
+		NewCreateHandler(repository, newPreparer(t)).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(recurringNotification)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestInvalidRequestOnCreate(t *testing.T) {
+	notification := `"notification":{"type":"sms","recipient":"+5511999999999","message":"Hello"}`
+	pastEndsAt := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	startsAt := time.Now().Add(48 * time.Hour).Format(time.RFC3339)
+	endsAt := time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+
+	testCases := []struct {
+		name                 string
+		body                 io.Reader
+		expectedStatusCode   int
+		expectedBodyContains string
+	}{
+		{"Should return 400 when body is invalid", strings.NewReader(`invalid`), http.StatusBadRequest, errInvalidBody.Error()},
+		{"Should return 422 when notification is missing", strings.NewReader(`{"cron":"0 9 * * *"}`), http.StatusUnprocessableEntity, `\"notification\"`},
+		{"Should return 422 when notification is not an object", strings.NewReader(`{"notification":"hello","cron":"0 9 * * *"}`), http.StatusUnprocessableEntity, `\"notification\"`},
+		{"Should return 422 when notification is invalid", strings.NewReader(`{"notification":{"type":"sms","recipient":"test@example.com","message":"Hello"},"cron":"0 9 * * *"}`), http.StatusUnprocessableEntity, `\"recipient\"`},
+		{"Should return 422 when notification has expires_at", strings.NewReader(`{"notification":{"type":"sms","recipient":"+5511999999999","message":"Hello","expires_at":"2030-01-01T10:00:00Z"},"cron":"0 9 * * *"}`), http.StatusUnprocessableEntity, `notification.expires_at`},
+		{"Should return 422 when neither rrule nor cron is informed", strings.NewReader(`{` + notification + `}`), http.StatusUnprocessableEntity, `\"rrule\"`},
+		{"Should return 422 when both rrule and cron are informed", strings.NewReader(`{` + notification + `,"rrule":"FREQ=DAILY","cron":"0 9 * * *"}`), http.StatusUnprocessableEntity, `\"cron\"`},
+		{"Should return 422 when rrule is invalid", strings.NewReader(`{` + notification + `,"rrule":"FREQ=SOMETIMES"}`), http.StatusUnprocessableEntity, `\"rrule\"`},
+		{"Should return 422 when cron is invalid", strings.NewReader(`{` + notification + `,"cron":"0 9 * *"}`), http.StatusUnprocessableEntity, `\"cron\"`},
+		{"Should return 422 when timezone is invalid", strings.NewReader(`{` + notification + `,"cron":"0 9 * * *","timezone":"Mars/Olympus"}`), http.StatusUnprocessableEntity, `\"timezone\"`},
+		{"Should return 422 when ends_at is in the past", strings.NewReader(`{` + notification + `,"cron":"0 9 * * *","ends_at":"` + pastEndsAt + `"}`), http.StatusUnprocessableEntity, `\"ends_at\"`},
+		{"Should return 422 when ends_at is before starts_at", strings.NewReader(`{` + notification + `,"cron":"0 9 * * *","starts_at":"` + startsAt + `","ends_at":"` + endsAt + `"}`), http.StatusUnprocessableEntity, `\"ends_at\"`},
+		{"Should return 422 when the schedule has no occurrence", strings.NewReader(`{` + notification + `,"rrule":"FREQ=DAILY;COUNT=1","starts_at":"2020-01-01T09:00:00Z"}`), http.StatusUnprocessableEntity, errNoOccurrence.Error()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/recurring-notifications", tc.body)
+
+			NewCreateHandler(mocks.NewRepository(t), newPreparer(t)).
+				Handler(response, request)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
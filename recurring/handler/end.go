@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"net/http"
+)
+
+var errAlreadyEnded = errors.New("the recurring notification has already ended")
+
+type EndHandler struct {
+	recurringRepository recurring.Repository
+}
+
+func NewEndHandler(recurringRepository recurring.Repository) *EndHandler {
+	return &EndHandler{recurringRepository: recurringRepository}
+}
+
+func (h *EndHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	found, err := h.recurringRepository.EndRecurringNotificationByID(id)
+
+	if err != nil {
+		if errors.Is(err, recurring.ErrInvalidStatusTransition) {
+			httputil.ConflictResponse(w, errAlreadyEnded)
+			return
+		}
+
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !found {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.NoContentResponse(w)
+}
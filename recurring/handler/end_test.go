@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"github.com/Tagliatti/magalu-challenge/recurring/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSuccessEnd(t *testing.T) {
+	t.Run("Should end a recurring notification successfully", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/recurring-notifications/1/end", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("EndRecurringNotificationByID", int64(1)).Return(true, nil)
+
+		NewEndHandler(repository).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		assert.Equal(t, "", response.Body.String())
+	})
+}
+
+func TestFailureOnEnd(t *testing.T) {
+	testCases := []struct {
+		name               string
+		found              bool
+		err                error
+		expectedStatusCode int
+		expectedBody       error
+	}{
+		{"Should return 409 when the recurring notification has already ended", false, recurring.ErrInvalidStatusTransition, http.StatusConflict, errAlreadyEnded},
+		{"Should return 404 when the recurring notification is not found", false, nil, http.StatusNotFound, errNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/recurring-notifications/1/end", nil)
+			request.SetPathValue("id", "1")
+
+			repository := mocks.NewRepository(t)
+			repository.On("EndRecurringNotificationByID", int64(1)).Return(tc.found, tc.err)
+
+			NewEndHandler(repository).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(httputil.NewErrorMessage(tc.expectedBody))
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+}
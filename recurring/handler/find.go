@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"net/http"
+)
+
+var errNotFound = errors.New("recurring notification not found")
+var errInvalidOrMissingId = errors.New("invalid or missing recurring notification id")
+
+type FindHandler struct {
+	recurringRepository recurring.Repository
+}
+
+func NewFindHandler(recurringRepository recurring.Repository) *FindHandler {
+	return &FindHandler{recurringRepository: recurringRepository}
+}
+
+func (h *FindHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	recurringNotification, err := h.recurringRepository.FindRecurringNotificationByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if recurringNotification == nil {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.OkResponse(w, recurringNotification)
+}
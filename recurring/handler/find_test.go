@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"github.com/Tagliatti/magalu-challenge/recurring/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	cron := "0 9 * * MON"
+	recurringNotification := &recurring.RecurringNotification{
+		Id:           1,
+		Notification: json.RawMessage(`{"type":"sms","recipient":"+5511999999999","message":"Hello"}`),
+		Cron:         &cron,
+		Timezone:     "UTC",
+		Status:       recurring.StatusActive,
+	}
+
+	testCases := []struct {
+		name                  string
+		recurringNotification *recurring.RecurringNotification
+		expectedStatusCode    int
+		expectedBody          any
+	}{
+		{"Should find recurring notification successfully", recurringNotification, http.StatusOK, recurringNotification},
+		{"Should return 404 when recurring notification not found", nil, http.StatusNotFound, httputil.NewErrorMessage(errNotFound)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("GET", "/recurring-notifications/1", nil)
+			request.SetPathValue("id", "1")
+
+			repository := mocks.NewRepository(t)
+			repository.On("FindRecurringNotificationByID", int64(1)).Return(tc.recurringNotification, nil)
+
+			NewFindHandler(repository).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(tc.expectedBody)
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+
+	t.Run("Should return 400 when id is invalid", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/recurring-notifications/invalid-id", nil)
+		request.SetPathValue("id", "invalid-id")
+
+		NewFindHandler(mocks.NewRepository(t)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), errInvalidOrMissingId.Error())
+	})
+}
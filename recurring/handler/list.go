@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/Oudwins/zog"
+	"github.com/Oudwins/zog/zhttp"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"net/http"
+)
+
+var listRecurringNotificationsSchema = zog.Struct(zog.Schema{
+	"status": zog.String().Trim().OneOf(recurring.Statuses),
+})
+
+type ListHandler struct {
+	recurringRepository recurring.Repository
+}
+
+func NewListHandler(recurringRepository recurring.Repository) *ListHandler {
+	return &ListHandler{recurringRepository: recurringRepository}
+}
+
+func (h *ListHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var filter recurring.ListRecurringNotificationsFilter
+
+	validationErrors := listRecurringNotificationsSchema.Parse(zhttp.Request(r), &filter)
+
+	if validationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(validationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	list, err := h.recurringRepository.ListRecurringNotifications(&filter)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.OkResponse(w, list)
+}
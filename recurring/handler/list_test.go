@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"github.com/Tagliatti/magalu-challenge/recurring/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	t.Run("Should list recurring notifications filtered by status", func(t *testing.T) {
+		rrule := "FREQ=MONTHLY;BYMONTHDAY=5"
+		list := []*recurring.RecurringNotification{
+			{Id: 1, Notification: json.RawMessage(`{"type":"sms","recipient":"+5511999999999","message":"Hello"}`), Rrule: &rrule, Timezone: "UTC", Status: recurring.StatusPaused},
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/recurring-notifications?status=paused", nil)
+
+		repository := mocks.NewRepository(t)
+		repository.On("ListRecurringNotifications", &recurring.ListRecurringNotificationsFilter{Status: "paused"}).Return(list, nil)
+
+		NewListHandler(repository).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(list)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+
+	t.Run("Should return 422 when the status is invalid", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/recurring-notifications?status=sleeping", nil)
+
+		NewListHandler(mocks.NewRepository(t)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Contains(t, response.Body.String(), `\"status\"`)
+	})
+}
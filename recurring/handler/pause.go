@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"net/http"
+)
+
+var errNotPausable = errors.New("only active recurring notifications can be paused")
+
+type PauseHandler struct {
+	recurringRepository recurring.Repository
+}
+
+func NewPauseHandler(recurringRepository recurring.Repository) *PauseHandler {
+	return &PauseHandler{recurringRepository: recurringRepository}
+}
+
+func (h *PauseHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	found, err := h.recurringRepository.PauseRecurringNotificationByID(id)
+
+	if err != nil {
+		if errors.Is(err, recurring.ErrInvalidStatusTransition) {
+			httputil.ConflictResponse(w, errNotPausable)
+			return
+		}
+
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !found {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.NoContentResponse(w)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"net/http"
+)
+
+var errNotResumable = errors.New("only paused recurring notifications can be resumed")
+
+type ResumeHandler struct {
+	recurringRepository recurring.Repository
+}
+
+func NewResumeHandler(recurringRepository recurring.Repository) *ResumeHandler {
+	return &ResumeHandler{recurringRepository: recurringRepository}
+}
+
+func (h *ResumeHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	found, err := h.recurringRepository.ResumeRecurringNotificationByID(id)
+
+	if err != nil {
+		if errors.Is(err, recurring.ErrInvalidStatusTransition) {
+			httputil.ConflictResponse(w, errNotResumable)
+			return
+		}
+
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !found {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.NoContentResponse(w)
+}
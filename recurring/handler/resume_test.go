@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"github.com/Tagliatti/magalu-challenge/recurring/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSuccessResume(t *testing.T) {
+	t.Run("Should resume a paused recurring notification successfully", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/recurring-notifications/1/resume", nil)
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("ResumeRecurringNotificationByID", int64(1)).Return(true, nil)
+
+		NewResumeHandler(repository).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusNoContent, response.Code)
+		assert.Equal(t, "", response.Body.String())
+	})
+}
+
+func TestFailureOnResume(t *testing.T) {
+	testCases := []struct {
+		name               string
+		found              bool
+		err                error
+		expectedStatusCode int
+		expectedBody       error
+	}{
+		{"Should return 409 when the recurring notification is not paused", false, recurring.ErrInvalidStatusTransition, http.StatusConflict, errNotResumable},
+		{"Should return 404 when the recurring notification is not found", false, nil, http.StatusNotFound, errNotFound},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/recurring-notifications/1/resume", nil)
+			request.SetPathValue("id", "1")
+
+			repository := mocks.NewRepository(t)
+			repository.On("ResumeRecurringNotificationByID", int64(1)).Return(tc.found, tc.err)
+
+			NewResumeHandler(repository).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(httputil.NewErrorMessage(tc.expectedBody))
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+}
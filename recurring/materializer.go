@@ -0,0 +1,149 @@
+package recurring
+
+import (
+	"context"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"log"
+	"time"
+)
+
+// Materializer creates the notifications of the active series ahead of time,
+// up to the configured horizon, so the dispatcher sends them like any other.
+type Materializer struct {
+	recurringRepository Repository
+	preparer            *prepare.Preparer
+	config              *Config
+}
+
+func NewMaterializer(recurringRepository Repository, preparer *prepare.Preparer, config *Config) *Materializer {
+	return &Materializer{
+		recurringRepository: recurringRepository,
+		preparer:            preparer,
+		config:              config,
+	}
+}
+
+// Run materializes the due series until ctx is canceled.
+func (m *Materializer) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := m.MaterializeDue(ctx); err != nil {
+			log.Printf("Erro ao gerar notificações recorrentes: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// MaterializeDue creates the occurrences of the claimed series up to the
+// horizon, returning how many notifications were created. Series that fail
+// are released to be retried on the next run.
+func (m *Materializer) MaterializeDue(ctx context.Context) (int, error) {
+	until := time.Now().Add(m.config.Horizon)
+	claimed, err := m.recurringRepository.ClaimDueRecurringNotifications(until, m.config.BatchSize, m.config.ClaimLease)
+
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+
+	for i, recurringNotification := range claimed {
+		if ctx.Err() != nil {
+			m.release(claimed[i:])
+			break
+		}
+
+		count, err := m.materialize(recurringNotification, until)
+
+		if err != nil {
+			log.Printf("Erro ao gerar notificações da recorrência %d: %v", recurringNotification.Id, err)
+			m.release(claimed[i : i+1])
+			continue
+		}
+
+		created += count
+	}
+
+	return created, nil
+}
+
+func (m *Materializer) materialize(recurringNotification *RecurringNotification, until time.Time) (int, error) {
+	schedule, err := recurringNotification.Schedule()
+
+	if err != nil {
+		return 0, err
+	}
+
+	// Occurrences missed while the materializer was down are skipped.
+	from := recurringNotification.MaterializedUntil
+
+	if now := time.Now(); from.Before(now) {
+		from = now
+	}
+
+	occurrences := make([]*notifications.CreateNotification, 0)
+	next := schedule.Next(from)
+
+	for !next.IsZero() && !next.After(until) {
+		occurrence, err := recurringNotification.Occurrence(next)
+
+		if err != nil {
+			return 0, err
+		}
+
+		occurrences = append(occurrences, occurrence)
+		next = schedule.Next(next)
+	}
+
+	valid := make([]*notifications.CreateNotification, 0, len(occurrences))
+
+	if len(occurrences) > 0 {
+		errs, err := m.preparer.Prepare(occurrences)
+
+		if err != nil {
+			return 0, err
+		}
+
+		for i, occurrence := range occurrences {
+			if validationError, ok := errs[i]; ok {
+				log.Printf("Ocorrência de %s da recorrência %d ignorada: %s",
+					occurrence.ScheduledAt.Format(time.RFC3339), recurringNotification.Id, validationError)
+				continue
+			}
+
+			valid = append(valid, occurrence)
+		}
+	}
+
+	// The series is advanced even without occurrences, so it is not claimed
+	// again before the next ones are due.
+	ids, err := m.recurringRepository.CreateOccurrences(recurringNotification, until, valid)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if next.IsZero() {
+		if _, err := m.recurringRepository.UpdateRecurringNotificationAsEnded(recurringNotification.Id); err != nil {
+			log.Printf("Erro ao encerrar a recorrência %d: %v", recurringNotification.Id, err)
+		}
+	}
+
+	return len(ids), nil
+}
+
+func (m *Materializer) release(recurringNotifications []*RecurringNotification) {
+	for _, recurringNotification := range recurringNotifications {
+		if _, err := m.recurringRepository.ReleaseRecurringNotification(recurringNotification.Id); err != nil {
+			log.Printf("Erro ao liberar a recorrência %d: %v", recurringNotification.Id, err)
+		}
+	}
+}
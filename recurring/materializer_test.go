@@ -0,0 +1,219 @@
+package recurring_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	contactMocks "github.com/Tagliatti/magalu-challenge/contacts/mocks"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	notificationMocks "github.com/Tagliatti/magalu-challenge/notifications/mocks"
+	"github.com/Tagliatti/magalu-challenge/notifications/prepare"
+	"github.com/Tagliatti/magalu-challenge/recurring"
+	"github.com/Tagliatti/magalu-challenge/recurring/mocks"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	suppressionMocks "github.com/Tagliatti/magalu-challenge/suppressions/mocks"
+	templateMocks "github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var testConfig = &recurring.Config{
+	PollInterval: time.Second,
+	Horizon:      3 * time.Hour,
+	BatchSize:    10,
+	ClaimLease:   time.Minute,
+}
+
+func newTestPreparer(t *testing.T) *prepare.Preparer {
+	suppressionRepository := suppressionMocks.NewRepository(t)
+	suppressionRepository.On("FindSuppressed", mock.Anything).Return(map[suppressions.Key]string{}, nil).Maybe()
+
+	return prepare.NewPreparer(notificationMocks.NewRepository(t), templateMocks.NewRepository(t), suppressionRepository, contactMocks.NewRepository(t), nil)
+}
+
+func TestSuccessMaterializeDue(t *testing.T) {
+	t.Run("Should create the occurrences up to the horizon", func(t *testing.T) {
+		cron := "*/30 * * * *"
+		recurringNotification := &recurring.RecurringNotification{
+			Id:                1,
+			Notification:      json.RawMessage(`{"type":"sms","recipient":"+5511999999999","message":"Hello"}`),
+			Cron:              &cron,
+			Timezone:          "UTC",
+			StartsAt:          time.Now().Add(-time.Hour),
+			Status:            recurring.StatusActive,
+			MaterializedUntil: time.Now(),
+		}
+
+		repository := mocks.NewRepository(t)
+		repository.On("ClaimDueRecurringNotifications", mock.Anything, testConfig.BatchSize, testConfig.ClaimLease).Return([]*recurring.RecurringNotification{recurringNotification}, nil)
+
+		repository.On("CreateOccurrences", recurringNotification, mock.Anything, mock.MatchedBy(func(createNotifications []*notifications.CreateNotification) bool {
+			for _, createNotification := range createNotifications {
+				if *createNotification.RecurringNotificationId != 1 || createNotification.ScheduledAt.Minute()%30 != 0 {
+					return false
+				}
+			}
+
+			return len(createNotifications) == 6
+		})).Return([]int64{1, 2, 3, 4, 5, 6}, nil)
+
+		created, err := recurring.NewMaterializer(repository, newTestPreparer(t), testConfig).
+			MaterializeDue(context.Background())
+
+		require.Nilf(t, err, "failed to materialize: %v", err)
+		assert.Equal(t, 6, created)
+	})
+
+	t.Run("Should end the series after its last occurrence", func(t *testing.T) {
+		rrule := "FREQ=HOURLY;COUNT=2"
+		recurringNotification := &recurring.RecurringNotification{
+			Id:                1,
+			Notification:      json.RawMessage(`{"type":"sms","recipient":"+5511999999999","message":"Hello"}`),
+			Rrule:             &rrule,
+			Timezone:          "UTC",
+			StartsAt:          time.Now().Add(time.Minute),
+			Status:            recurring.StatusActive,
+			MaterializedUntil: time.Now(),
+		}
+
+		repository := mocks.NewRepository(t)
+		repository.On("ClaimDueRecurringNotifications", mock.Anything, testConfig.BatchSize, testConfig.ClaimLease).Return([]*recurring.RecurringNotification{recurringNotification}, nil)
+		repository.On("UpdateRecurringNotificationAsEnded", int64(1)).Return(true, nil)
+
+		repository.On("CreateOccurrences", recurringNotification, mock.Anything, mock.MatchedBy(func(createNotifications []*notifications.CreateNotification) bool {
+			return len(createNotifications) == 2
+		})).Return([]int64{1, 2}, nil)
+
+		created, err := recurring.NewMaterializer(repository, newTestPreparer(t), testConfig).
+			MaterializeDue(context.Background())
+
+		require.Nilf(t, err, "failed to materialize: %v", err)
+		assert.Equal(t, 2, created)
+	})
+}
+
+func TestChangedSeriesOnMaterializeDue(t *testing.T) {
+	t.Run("Should create nothing when the series was paused since it was claimed", func(t *testing.T) {
+		cron := "*/30 * * * *"
+		recurringNotification := &recurring.RecurringNotification{
+			Id:                1,
+			Notification:      json.RawMessage(`{"type":"sms","recipient":"+5511999999999","message":"Hello"}`),
+			Cron:              &cron,
+			Timezone:          "UTC",
+			StartsAt:          time.Now().Add(-time.Hour),
+			Status:            recurring.StatusActive,
+			MaterializedUntil: time.Now(),
+		}
+
+		repository := mocks.NewRepository(t)
+		repository.On("ClaimDueRecurringNotifications", mock.Anything, testConfig.BatchSize, testConfig.ClaimLease).Return([]*recurring.RecurringNotification{recurringNotification}, nil)
+		repository.On("CreateOccurrences", recurringNotification, mock.Anything, mock.Anything).Return(nil, nil)
+
+		created, err := recurring.NewMaterializer(repository, newTestPreparer(t), testConfig).
+			MaterializeDue(context.Background())
+
+		require.Nilf(t, err, "failed to materialize: %v", err)
+		assert.Equal(t, 0, created)
+	})
+}
+
+func TestInvalidOccurrenceOnMaterializeDue(t *testing.T) {
+	t.Run("Should skip occurrences that can not be created", func(t *testing.T) {
+		cron := "*/30 * * * *"
+		recurringNotification := &recurring.RecurringNotification{
+			Id:                1,
+			Notification:      json.RawMessage(`{"type":"sms","recipient":"test@example.com","message":"Hello"}`),
+			Cron:              &cron,
+			Timezone:          "UTC",
+			StartsAt:          time.Now().Add(-time.Hour),
+			Status:            recurring.StatusActive,
+			MaterializedUntil: time.Now(),
+		}
+
+		repository := mocks.NewRepository(t)
+		repository.On("ClaimDueRecurringNotifications", mock.Anything, testConfig.BatchSize, testConfig.ClaimLease).Return([]*recurring.RecurringNotification{recurringNotification}, nil)
+		repository.On("CreateOccurrences", recurringNotification, mock.Anything, []*notifications.CreateNotification{}).Return(nil, nil)
+
+		created, err := recurring.NewMaterializer(repository, newTestPreparer(t), testConfig).
+			MaterializeDue(context.Background())
+
+		require.Nilf(t, err, "failed to materialize: %v", err)
+		assert.Equal(t, 0, created)
+	})
+}
+
+func TestFrequencyCapOnMaterializeDue(t *testing.T) {
+	t.Run("Should skip the occurrences over the frequency cap", func(t *testing.T) {
+		cron := "*/30 * * * *"
+		recurringNotification := &recurring.RecurringNotification{
+			Id:                1,
+			Notification:      json.RawMessage(`{"type":"sms","recipient":"+5511999999999","message":"Hello"}`),
+			Cron:              &cron,
+			Timezone:          "UTC",
+			StartsAt:          time.Now().Add(-time.Hour),
+			Status:            recurring.StatusActive,
+			MaterializedUntil: time.Now(),
+		}
+
+		repository := mocks.NewRepository(t)
+		repository.On("ClaimDueRecurringNotifications", mock.Anything, testConfig.BatchSize, testConfig.ClaimLease).Return([]*recurring.RecurringNotification{recurringNotification}, nil)
+		repository.On("CreateOccurrences", recurringNotification, mock.Anything, mock.MatchedBy(func(createNotifications []*notifications.CreateNotification) bool {
+			return len(createNotifications) == 3
+		})).Return([]int64{1, 2, 3}, nil)
+
+		notificationRepository := notificationMocks.NewRepository(t)
+		notificationRepository.On("FindRecipientsDeliveries", mock.Anything).
+			Return(map[notifications.RecipientKey][]time.Time{{Type: "sms", Recipient: "+5511999999999"}: {}}, nil).Once()
+
+		suppressionRepository := suppressionMocks.NewRepository(t)
+		suppressionRepository.On("FindSuppressed", mock.Anything).Return(map[suppressions.Key]string{}, nil)
+
+		preparer := prepare.NewPreparer(notificationRepository, templateMocks.NewRepository(t), suppressionRepository, contactMocks.NewRepository(t),
+			notifications.FrequencyCaps{"sms": {Max: 1, Window: time.Hour, Mode: notifications.FrequencyCapReject}})
+
+		created, err := recurring.NewMaterializer(repository, preparer, testConfig).
+			MaterializeDue(context.Background())
+
+		require.Nilf(t, err, "failed to materialize: %v", err)
+		assert.Equal(t, 3, created)
+	})
+}
+
+func TestFailureOnMaterializeDue(t *testing.T) {
+	t.Run("Should release the series when creating its occurrences fails", func(t *testing.T) {
+		cron := "*/30 * * * *"
+		recurringNotification := &recurring.RecurringNotification{
+			Id:                1,
+			Notification:      json.RawMessage(`{"type":"sms","recipient":"+5511999999999","message":"Hello"}`),
+			Cron:              &cron,
+			Timezone:          "UTC",
+			StartsAt:          time.Now().Add(-time.Hour),
+			Status:            recurring.StatusActive,
+			MaterializedUntil: time.Now(),
+		}
+
+		repository := mocks.NewRepository(t)
+		repository.On("ClaimDueRecurringNotifications", mock.Anything, testConfig.BatchSize, testConfig.ClaimLease).Return([]*recurring.RecurringNotification{recurringNotification}, nil)
+		repository.On("CreateOccurrences", recurringNotification, mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+		repository.On("ReleaseRecurringNotification", int64(1)).Return(true, nil)
+
+		created, err := recurring.NewMaterializer(repository, newTestPreparer(t), testConfig).
+			MaterializeDue(context.Background())
+
+		require.Nilf(t, err, "failed to materialize: %v", err)
+		assert.Equal(t, 0, created)
+	})
+
+	t.Run("Should return the error when claiming fails", func(t *testing.T) {
+		repository := mocks.NewRepository(t)
+		repository.On("ClaimDueRecurringNotifications", mock.Anything, testConfig.BatchSize, testConfig.ClaimLease).Return(nil, errors.New("connection refused"))
+
+		_, err := recurring.NewMaterializer(repository, newTestPreparer(t), testConfig).
+			MaterializeDue(context.Background())
+
+		assert.NotNil(t, err)
+	})
+}
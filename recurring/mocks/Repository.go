@@ -0,0 +1,610 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	notifications "github.com/Tagliatti/magalu-challenge/notifications"
+	recurring "github.com/Tagliatti/magalu-challenge/recurring"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDueRecurringNotifications provides a mock function with given fields: until, limit, lease
+func (_m *Repository) ClaimDueRecurringNotifications(until time.Time, limit int, lease time.Duration) ([]*recurring.RecurringNotification, error) {
+	ret := _m.Called(until, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueRecurringNotifications")
+	}
+
+	var r0 []*recurring.RecurringNotification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, int, time.Duration) ([]*recurring.RecurringNotification, error)); ok {
+		return rf(until, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, int, time.Duration) []*recurring.RecurringNotification); ok {
+		r0 = rf(until, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*recurring.RecurringNotification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, int, time.Duration) error); ok {
+		r1 = rf(until, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ClaimDueRecurringNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDueRecurringNotifications'
+type Repository_ClaimDueRecurringNotifications_Call struct {
+	*mock.Call
+}
+
+// ClaimDueRecurringNotifications is a helper method to define mock.On call
+//   - until time.Time
+//   - limit int
+//   - lease time.Duration
+func (_e *Repository_Expecter) ClaimDueRecurringNotifications(until interface{}, limit interface{}, lease interface{}) *Repository_ClaimDueRecurringNotifications_Call {
+	return &Repository_ClaimDueRecurringNotifications_Call{Call: _e.mock.On("ClaimDueRecurringNotifications", until, limit, lease)}
+}
+
+func (_c *Repository_ClaimDueRecurringNotifications_Call) Run(run func(until time.Time, limit int, lease time.Duration)) *Repository_ClaimDueRecurringNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time), args[1].(int), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *Repository_ClaimDueRecurringNotifications_Call) Return(_a0 []*recurring.RecurringNotification, _a1 error) *Repository_ClaimDueRecurringNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ClaimDueRecurringNotifications_Call) RunAndReturn(run func(time.Time, int, time.Duration) ([]*recurring.RecurringNotification, error)) *Repository_ClaimDueRecurringNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOccurrences provides a mock function with given fields: recurringNotification, until, occurrences
+func (_m *Repository) CreateOccurrences(recurringNotification *recurring.RecurringNotification, until time.Time, occurrences []*notifications.CreateNotification) ([]int64, error) {
+	ret := _m.Called(recurringNotification, until, occurrences)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOccurrences")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*recurring.RecurringNotification, time.Time, []*notifications.CreateNotification) ([]int64, error)); ok {
+		return rf(recurringNotification, until, occurrences)
+	}
+	if rf, ok := ret.Get(0).(func(*recurring.RecurringNotification, time.Time, []*notifications.CreateNotification) []int64); ok {
+		r0 = rf(recurringNotification, until, occurrences)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*recurring.RecurringNotification, time.Time, []*notifications.CreateNotification) error); ok {
+		r1 = rf(recurringNotification, until, occurrences)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_CreateOccurrences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOccurrences'
+type Repository_CreateOccurrences_Call struct {
+	*mock.Call
+}
+
+// CreateOccurrences is a helper method to define mock.On call
+//   - recurringNotification *recurring.RecurringNotification
+//   - until time.Time
+//   - occurrences []*notifications.CreateNotification
+func (_e *Repository_Expecter) CreateOccurrences(recurringNotification interface{}, until interface{}, occurrences interface{}) *Repository_CreateOccurrences_Call {
+	return &Repository_CreateOccurrences_Call{Call: _e.mock.On("CreateOccurrences", recurringNotification, until, occurrences)}
+}
+
+func (_c *Repository_CreateOccurrences_Call) Run(run func(recurringNotification *recurring.RecurringNotification, until time.Time, occurrences []*notifications.CreateNotification)) *Repository_CreateOccurrences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*recurring.RecurringNotification), args[1].(time.Time), args[2].([]*notifications.CreateNotification))
+	})
+	return _c
+}
+
+func (_c *Repository_CreateOccurrences_Call) Return(_a0 []int64, _a1 error) *Repository_CreateOccurrences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_CreateOccurrences_Call) RunAndReturn(run func(*recurring.RecurringNotification, time.Time, []*notifications.CreateNotification) ([]int64, error)) *Repository_CreateOccurrences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRecurringNotification provides a mock function with given fields: createRecurringNotification
+func (_m *Repository) CreateRecurringNotification(createRecurringNotification *recurring.CreateRecurringNotification) (int64, error) {
+	ret := _m.Called(createRecurringNotification)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecurringNotification")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*recurring.CreateRecurringNotification) (int64, error)); ok {
+		return rf(createRecurringNotification)
+	}
+	if rf, ok := ret.Get(0).(func(*recurring.CreateRecurringNotification) int64); ok {
+		r0 = rf(createRecurringNotification)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*recurring.CreateRecurringNotification) error); ok {
+		r1 = rf(createRecurringNotification)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_CreateRecurringNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRecurringNotification'
+type Repository_CreateRecurringNotification_Call struct {
+	*mock.Call
+}
+
+// CreateRecurringNotification is a helper method to define mock.On call
+//   - createRecurringNotification *recurring.CreateRecurringNotification
+func (_e *Repository_Expecter) CreateRecurringNotification(createRecurringNotification interface{}) *Repository_CreateRecurringNotification_Call {
+	return &Repository_CreateRecurringNotification_Call{Call: _e.mock.On("CreateRecurringNotification", createRecurringNotification)}
+}
+
+func (_c *Repository_CreateRecurringNotification_Call) Run(run func(createRecurringNotification *recurring.CreateRecurringNotification)) *Repository_CreateRecurringNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*recurring.CreateRecurringNotification))
+	})
+	return _c
+}
+
+func (_c *Repository_CreateRecurringNotification_Call) Return(_a0 int64, _a1 error) *Repository_CreateRecurringNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_CreateRecurringNotification_Call) RunAndReturn(run func(*recurring.CreateRecurringNotification) (int64, error)) *Repository_CreateRecurringNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EndRecurringNotificationByID provides a mock function with given fields: id
+func (_m *Repository) EndRecurringNotificationByID(id int64) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndRecurringNotificationByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_EndRecurringNotificationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EndRecurringNotificationByID'
+type Repository_EndRecurringNotificationByID_Call struct {
+	*mock.Call
+}
+
+// EndRecurringNotificationByID is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) EndRecurringNotificationByID(id interface{}) *Repository_EndRecurringNotificationByID_Call {
+	return &Repository_EndRecurringNotificationByID_Call{Call: _e.mock.On("EndRecurringNotificationByID", id)}
+}
+
+func (_c *Repository_EndRecurringNotificationByID_Call) Run(run func(id int64)) *Repository_EndRecurringNotificationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_EndRecurringNotificationByID_Call) Return(_a0 bool, _a1 error) *Repository_EndRecurringNotificationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_EndRecurringNotificationByID_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_EndRecurringNotificationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRecurringNotificationByID provides a mock function with given fields: id
+func (_m *Repository) FindRecurringNotificationByID(id int64) (*recurring.RecurringNotification, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRecurringNotificationByID")
+	}
+
+	var r0 *recurring.RecurringNotification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*recurring.RecurringNotification, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *recurring.RecurringNotification); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*recurring.RecurringNotification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindRecurringNotificationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRecurringNotificationByID'
+type Repository_FindRecurringNotificationByID_Call struct {
+	*mock.Call
+}
+
+// FindRecurringNotificationByID is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) FindRecurringNotificationByID(id interface{}) *Repository_FindRecurringNotificationByID_Call {
+	return &Repository_FindRecurringNotificationByID_Call{Call: _e.mock.On("FindRecurringNotificationByID", id)}
+}
+
+func (_c *Repository_FindRecurringNotificationByID_Call) Run(run func(id int64)) *Repository_FindRecurringNotificationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_FindRecurringNotificationByID_Call) Return(_a0 *recurring.RecurringNotification, _a1 error) *Repository_FindRecurringNotificationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindRecurringNotificationByID_Call) RunAndReturn(run func(int64) (*recurring.RecurringNotification, error)) *Repository_FindRecurringNotificationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRecurringNotifications provides a mock function with given fields: filter
+func (_m *Repository) ListRecurringNotifications(filter *recurring.ListRecurringNotificationsFilter) ([]*recurring.RecurringNotification, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecurringNotifications")
+	}
+
+	var r0 []*recurring.RecurringNotification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*recurring.ListRecurringNotificationsFilter) ([]*recurring.RecurringNotification, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*recurring.ListRecurringNotificationsFilter) []*recurring.RecurringNotification); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*recurring.RecurringNotification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*recurring.ListRecurringNotificationsFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListRecurringNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRecurringNotifications'
+type Repository_ListRecurringNotifications_Call struct {
+	*mock.Call
+}
+
+// ListRecurringNotifications is a helper method to define mock.On call
+//   - filter *recurring.ListRecurringNotificationsFilter
+func (_e *Repository_Expecter) ListRecurringNotifications(filter interface{}) *Repository_ListRecurringNotifications_Call {
+	return &Repository_ListRecurringNotifications_Call{Call: _e.mock.On("ListRecurringNotifications", filter)}
+}
+
+func (_c *Repository_ListRecurringNotifications_Call) Run(run func(filter *recurring.ListRecurringNotificationsFilter)) *Repository_ListRecurringNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*recurring.ListRecurringNotificationsFilter))
+	})
+	return _c
+}
+
+func (_c *Repository_ListRecurringNotifications_Call) Return(_a0 []*recurring.RecurringNotification, _a1 error) *Repository_ListRecurringNotifications_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListRecurringNotifications_Call) RunAndReturn(run func(*recurring.ListRecurringNotificationsFilter) ([]*recurring.RecurringNotification, error)) *Repository_ListRecurringNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseRecurringNotificationByID provides a mock function with given fields: id
+func (_m *Repository) PauseRecurringNotificationByID(id int64) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseRecurringNotificationByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_PauseRecurringNotificationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseRecurringNotificationByID'
+type Repository_PauseRecurringNotificationByID_Call struct {
+	*mock.Call
+}
+
+// PauseRecurringNotificationByID is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) PauseRecurringNotificationByID(id interface{}) *Repository_PauseRecurringNotificationByID_Call {
+	return &Repository_PauseRecurringNotificationByID_Call{Call: _e.mock.On("PauseRecurringNotificationByID", id)}
+}
+
+func (_c *Repository_PauseRecurringNotificationByID_Call) Run(run func(id int64)) *Repository_PauseRecurringNotificationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_PauseRecurringNotificationByID_Call) Return(_a0 bool, _a1 error) *Repository_PauseRecurringNotificationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_PauseRecurringNotificationByID_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_PauseRecurringNotificationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseRecurringNotification provides a mock function with given fields: id
+func (_m *Repository) ReleaseRecurringNotification(id int64) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseRecurringNotification")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ReleaseRecurringNotification_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseRecurringNotification'
+type Repository_ReleaseRecurringNotification_Call struct {
+	*mock.Call
+}
+
+// ReleaseRecurringNotification is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) ReleaseRecurringNotification(id interface{}) *Repository_ReleaseRecurringNotification_Call {
+	return &Repository_ReleaseRecurringNotification_Call{Call: _e.mock.On("ReleaseRecurringNotification", id)}
+}
+
+func (_c *Repository_ReleaseRecurringNotification_Call) Run(run func(id int64)) *Repository_ReleaseRecurringNotification_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_ReleaseRecurringNotification_Call) Return(_a0 bool, _a1 error) *Repository_ReleaseRecurringNotification_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ReleaseRecurringNotification_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_ReleaseRecurringNotification_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeRecurringNotificationByID provides a mock function with given fields: id
+func (_m *Repository) ResumeRecurringNotificationByID(id int64) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeRecurringNotificationByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ResumeRecurringNotificationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeRecurringNotificationByID'
+type Repository_ResumeRecurringNotificationByID_Call struct {
+	*mock.Call
+}
+
+// ResumeRecurringNotificationByID is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) ResumeRecurringNotificationByID(id interface{}) *Repository_ResumeRecurringNotificationByID_Call {
+	return &Repository_ResumeRecurringNotificationByID_Call{Call: _e.mock.On("ResumeRecurringNotificationByID", id)}
+}
+
+func (_c *Repository_ResumeRecurringNotificationByID_Call) Run(run func(id int64)) *Repository_ResumeRecurringNotificationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_ResumeRecurringNotificationByID_Call) Return(_a0 bool, _a1 error) *Repository_ResumeRecurringNotificationByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ResumeRecurringNotificationByID_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_ResumeRecurringNotificationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateRecurringNotificationAsEnded provides a mock function with given fields: id
+func (_m *Repository) UpdateRecurringNotificationAsEnded(id int64) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRecurringNotificationAsEnded")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UpdateRecurringNotificationAsEnded_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateRecurringNotificationAsEnded'
+type Repository_UpdateRecurringNotificationAsEnded_Call struct {
+	*mock.Call
+}
+
+// UpdateRecurringNotificationAsEnded is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) UpdateRecurringNotificationAsEnded(id interface{}) *Repository_UpdateRecurringNotificationAsEnded_Call {
+	return &Repository_UpdateRecurringNotificationAsEnded_Call{Call: _e.mock.On("UpdateRecurringNotificationAsEnded", id)}
+}
+
+func (_c *Repository_UpdateRecurringNotificationAsEnded_Call) Run(run func(id int64)) *Repository_UpdateRecurringNotificationAsEnded_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateRecurringNotificationAsEnded_Call) Return(_a0 bool, _a1 error) *Repository_UpdateRecurringNotificationAsEnded_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_UpdateRecurringNotificationAsEnded_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_UpdateRecurringNotificationAsEnded_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
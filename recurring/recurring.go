@@ -0,0 +1,113 @@
+package recurring
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusActive Status = "active"
+	StatusPaused Status = "paused"
+	StatusEnded  Status = "ended"
+)
+
+var Statuses = []string{
+	string(StatusActive),
+	string(StatusPaused),
+	string(StatusEnded),
+}
+
+var ErrInvalidStatusTransition = errors.New("invalid recurring notification status transition")
+
+var statusTransitions = map[Status][]Status{
+	StatusActive: {StatusPaused, StatusEnded},
+	StatusPaused: {StatusActive, StatusEnded},
+}
+
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RecurringNotification is a series of notifications created from Notification,
+// the body of a POST /notifications without scheduled_at, at every occurrence
+// of its schedule. Occurrences up to MaterializedUntil were already created.
+type RecurringNotification struct {
+	Id                int64           `json:"id"`
+	Notification      json.RawMessage `json:"notification"`
+	Rrule             *string         `json:"rrule"`
+	Cron              *string         `json:"cron"`
+	Timezone          string          `json:"timezone"`
+	StartsAt          time.Time       `json:"starts_at"`
+	EndsAt            *time.Time      `json:"ends_at"`
+	Status            Status          `json:"status"`
+	MaterializedUntil time.Time       `json:"materialized_until"`
+	CreatedAt         time.Time       `json:"created_at"`
+	PausedAt          *time.Time      `json:"paused_at"`
+	EndedAt           *time.Time      `json:"ended_at"`
+}
+
+type CreateRecurringNotification struct {
+	Notification      json.RawMessage `json:"notification"`
+	Rrule             string          `json:"rrule"`
+	Cron              string          `json:"cron"`
+	Timezone          string          `json:"timezone"`
+	StartsAt          time.Time       `json:"starts_at" zog:"starts_at"`
+	EndsAt            *time.Time      `json:"ends_at" zog:"ends_at"`
+	MaterializedUntil time.Time       `json:"-"`
+}
+
+type ListRecurringNotificationsFilter struct {
+	Status string `zog:"status"`
+}
+
+// Schedule returns the schedule of the series, bounded by its start and end.
+func (r *RecurringNotification) Schedule() (Schedule, error) {
+	var rrule, cron string
+
+	if r.Rrule != nil {
+		rrule = *r.Rrule
+	}
+
+	if r.Cron != nil {
+		cron = *r.Cron
+	}
+
+	return ParseSchedule(rrule, cron, r.Timezone, r.StartsAt, r.EndsAt)
+}
+
+// Occurrence returns the notification of the series scheduled at scheduledAt.
+func (r *RecurringNotification) Occurrence(scheduledAt time.Time) (*notifications.CreateNotification, error) {
+	occurrence, err := NewOccurrence(r.Notification, scheduledAt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	occurrence.RecurringNotificationId = &r.Id
+
+	return occurrence, nil
+}
+
+// NewOccurrence decodes the notification of a series, scheduling it at
+// scheduledAt.
+func NewOccurrence(notification json.RawMessage, scheduledAt time.Time) (*notifications.CreateNotification, error) {
+	var occurrence notifications.CreateNotification
+
+	if err := json.Unmarshal(notification, &occurrence); err != nil {
+		return nil, err
+	}
+
+	occurrence.ScheduledAt = scheduledAt
+
+	return &occurrence, nil
+}
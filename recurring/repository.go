@@ -0,0 +1,356 @@
+package recurring
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"time"
+)
+
+type Repository interface {
+	CreateRecurringNotification(createRecurringNotification *CreateRecurringNotification) (int64, error)
+	FindRecurringNotificationByID(id int64) (*RecurringNotification, error)
+	ListRecurringNotifications(filter *ListRecurringNotificationsFilter) ([]*RecurringNotification, error)
+	PauseRecurringNotificationByID(id int64) (bool, error)
+	ResumeRecurringNotificationByID(id int64) (bool, error)
+	EndRecurringNotificationByID(id int64) (bool, error)
+	ClaimDueRecurringNotifications(until time.Time, limit int, lease time.Duration) ([]*RecurringNotification, error)
+	ReleaseRecurringNotification(id int64) (bool, error)
+	CreateOccurrences(recurringNotification *RecurringNotification, until time.Time, occurrences []*notifications.CreateNotification) ([]int64, error)
+	UpdateRecurringNotificationAsEnded(id int64) (bool, error)
+}
+
+const recurringNotificationColumns = `id, notification, rrule, cron, timezone, starts_at, ends_at, status, materialized_until, created_at, paused_at, ended_at`
+
+var statusTimestampColumns = map[Status]string{
+	StatusPaused: "paused_at",
+	StatusEnded:  "ended_at",
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) CreateRecurringNotification(createRecurringNotification *CreateRecurringNotification) (int64, error) {
+	var id int64
+
+	err := r.db.QueryRow(`
+		INSERT INTO recurring_notifications (notification, rrule, cron, timezone, starts_at, ends_at, materialized_until)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, ''), $4, $5, $6, $7)
+		RETURNING id`,
+		createRecurringNotification.Notification,
+		createRecurringNotification.Rrule,
+		createRecurringNotification.Cron,
+		createRecurringNotification.Timezone,
+		createRecurringNotification.StartsAt,
+		createRecurringNotification.EndsAt,
+		createRecurringNotification.MaterializedUntil,
+	).Scan(&id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *PostgresRepository) FindRecurringNotificationByID(id int64) (*RecurringNotification, error) {
+	row := r.db.QueryRow(`SELECT `+recurringNotificationColumns+` FROM recurring_notifications WHERE id = $1`, id)
+	recurringNotification, err := scanRecurringNotification(row)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return recurringNotification, nil
+}
+
+func (r *PostgresRepository) ListRecurringNotifications(filter *ListRecurringNotificationsFilter) ([]*RecurringNotification, error) {
+	query := `SELECT ` + recurringNotificationColumns + ` FROM recurring_notifications`
+	args := make([]any, 0)
+
+	if filter.Status != "" {
+		query += ` WHERE status = $1`
+		args = append(args, filter.Status)
+	}
+
+	rows, err := r.db.Query(query+` ORDER BY id`, args...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recurringNotifications := make([]*RecurringNotification, 0)
+
+	for rows.Next() {
+		recurringNotification, err := scanRecurringNotification(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		recurringNotifications = append(recurringNotifications, recurringNotification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return recurringNotifications, nil
+}
+
+// PauseRecurringNotificationByID stops creating notifications for the series,
+// canceling the ones already created that were not sent yet.
+func (r *PostgresRepository) PauseRecurringNotificationByID(id int64) (bool, error) {
+	return r.transition(id, StatusPaused)
+}
+
+// ResumeRecurringNotificationByID creates notifications for the series again from
+// now on; the occurrences missed while it was paused are skipped.
+func (r *PostgresRepository) ResumeRecurringNotificationByID(id int64) (bool, error) {
+	return r.transition(id, StatusActive)
+}
+
+// EndRecurringNotificationByID finishes the series for good, canceling the
+// notifications already created that were not sent yet.
+func (r *PostgresRepository) EndRecurringNotificationByID(id int64) (bool, error) {
+	return r.transition(id, StatusEnded)
+}
+
+// ClaimDueRecurringNotifications reserves up to limit active series that are
+// behind until, so other runs skip them while their occurrences are created.
+// Series reserved for longer than lease (e.g. the replica holding them crashed)
+// are claimed again.
+func (r *PostgresRepository) ClaimDueRecurringNotifications(until time.Time, limit int, lease time.Duration) ([]*RecurringNotification, error) {
+	rows, err := r.db.Query(`
+		UPDATE recurring_notifications SET claimed_at = NOW()
+		WHERE id IN (
+			SELECT id FROM recurring_notifications
+			WHERE status = 'active' AND materialized_until < $1
+			  AND (claimed_at IS NULL OR claimed_at < NOW() - make_interval(secs => $3))
+			ORDER BY materialized_until
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+recurringNotificationColumns,
+		until,
+		limit,
+		lease.Seconds(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	claimed := make([]*RecurringNotification, 0, limit)
+
+	for rows.Next() {
+		recurringNotification, err := scanRecurringNotification(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		claimed = append(claimed, recurringNotification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+// ReleaseRecurringNotification gives up the reservation of a claimed series, so
+// its occurrences are created on the next run.
+func (r *PostgresRepository) ReleaseRecurringNotification(id int64) (bool, error) {
+	result, err := r.db.Exec(`UPDATE recurring_notifications SET claimed_at = NULL WHERE id = $1`, id)
+
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CreateOccurrences creates the occurrences of a claimed series and moves its
+// materialized_until to until in a single transaction, releasing it. When the
+// series was paused, ended or resumed since it was claimed, nothing is created
+// and it returns no ids.
+func (r *PostgresRepository) CreateOccurrences(recurringNotification *RecurringNotification, until time.Time, occurrences []*notifications.CreateNotification) ([]int64, error) {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Locking the series makes a concurrent pause or end wait for the
+	// occurrences, and then cancel them.
+	result, err := tx.Exec(`
+		UPDATE recurring_notifications SET materialized_until = $3, claimed_at = NULL
+		WHERE id = $1 AND status = 'active' AND materialized_until = $2`,
+		recurringNotification.Id,
+		recurringNotification.MaterializedUntil,
+		until,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if rowsAffected == 0 {
+		_, err = tx.Exec(`UPDATE recurring_notifications SET claimed_at = NULL WHERE id = $1`, recurringNotification.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, tx.Commit()
+	}
+
+	var ids []int64
+
+	if len(occurrences) > 0 {
+		ids, err = notifications.InsertNotifications(tx, occurrences)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// UpdateRecurringNotificationAsEnded finishes an active series whose last
+// occurrence was created, keeping its notifications.
+func (r *PostgresRepository) UpdateRecurringNotificationAsEnded(id int64) (bool, error) {
+	result, err := r.db.Exec(`UPDATE recurring_notifications SET status = 'ended', ended_at = NOW() WHERE id = $1 AND status = 'active'`, id)
+
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *PostgresRepository) transition(id int64, next Status) (bool, error) {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var current Status
+	err = tx.QueryRow(`SELECT status FROM recurring_notifications WHERE id = $1 FOR UPDATE`, id).Scan(&current)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	if !current.CanTransitionTo(next) {
+		return false, ErrInvalidStatusTransition
+	}
+
+	if next == StatusActive {
+		_, err = tx.Exec(`UPDATE recurring_notifications SET status = $2, paused_at = NULL, materialized_until = NOW() WHERE id = $1`, id, next)
+	} else {
+		_, err = tx.Exec(`UPDATE recurring_notifications SET status = $2, `+statusTimestampColumns[next]+` = NOW() WHERE id = $1`, id, next)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	if next != StatusActive {
+		_, err = tx.Exec(`
+			WITH canceled AS (
+				UPDATE notifications SET status = 'canceled', canceled_at = NOW(), cancel_reason = $2
+				WHERE recurring_notification_id = $1 AND status IN ('scheduled', 'queued')
+				RETURNING id, status
+			)
+			INSERT INTO notification_events (notification_id, event, status, actor)
+			SELECT id, $3, status, $4 FROM canceled`,
+			id,
+			fmt.Sprintf("recurring notification %s", next),
+			notifications.StatusCanceled,
+			notifications.ActorAPI,
+		)
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func scanRecurringNotification(row rowScanner) (*RecurringNotification, error) {
+	var recurringNotification RecurringNotification
+	err := row.Scan(
+		&recurringNotification.Id,
+		&recurringNotification.Notification,
+		&recurringNotification.Rrule,
+		&recurringNotification.Cron,
+		&recurringNotification.Timezone,
+		&recurringNotification.StartsAt,
+		&recurringNotification.EndsAt,
+		&recurringNotification.Status,
+		&recurringNotification.MaterializedUntil,
+		&recurringNotification.CreatedAt,
+		&recurringNotification.PausedAt,
+		&recurringNotification.EndedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &recurringNotification, nil
+}
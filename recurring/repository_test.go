@@ -0,0 +1,346 @@
+package recurring
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/database"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type PostgresRepositoryTestSuite struct {
+	suite.Suite
+	pgContainer *testhelpers.PostgresContainer
+	repository  *PostgresRepository
+	db          *sql.DB
+	ctx         context.Context
+}
+
+func (suite *PostgresRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	pgContainer, err := testhelpers.NewPostgresContainer(suite.ctx)
+	require.Nil(suite.T(), err, "failed to start postgres container: %v", err)
+
+	suite.pgContainer = pgContainer
+
+	db, err := database.ConnectTest(pgContainer.ConnectionString)
+	require.Nil(suite.T(), err, "failed to connect to database: %v", err)
+
+	suite.db = db
+	suite.repository = NewPostgresRepository(db)
+}
+
+func (suite *PostgresRepositoryTestSuite) TearDownSuite() {
+	if err := suite.pgContainer.Terminate(suite.ctx); err != nil {
+		suite.T().Fatalf("failed to terminate pgContainer: %s", err)
+	}
+}
+
+func TestPostgresRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PostgresRepositoryTestSuite))
+}
+
+func (suite *PostgresRepositoryTestSuite) createRecurringNotification(t *testing.T, materializedUntil time.Time) int64 {
+	id, err := suite.repository.CreateRecurringNotification(&CreateRecurringNotification{
+		Notification:      json.RawMessage(`{"type":"sms","recipient":"+5511999999999","message":"Hello"}`),
+		Cron:              "0 9 * * *",
+		Timezone:          "America/Sao_Paulo",
+		StartsAt:          time.Now(),
+		MaterializedUntil: materializedUntil,
+	})
+	require.Nilf(t, err, "failed to create recurring notification: %v", err)
+
+	return id
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessCreateRecurringNotification() {
+	t := suite.T()
+
+	t.Run("Should create recurring notification successfully", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id := suite.createRecurringNotification(t, time.Now())
+
+		recurringNotification, err := suite.repository.FindRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to find recurring notification: %v", err)
+		require.NotNil(t, recurringNotification)
+
+		assert.JSONEq(t, `{"type":"sms","recipient":"+5511999999999","message":"Hello"}`, string(recurringNotification.Notification))
+		assert.Nil(t, recurringNotification.Rrule)
+		assert.Equal(t, "0 9 * * *", *recurringNotification.Cron)
+		assert.Equal(t, "America/Sao_Paulo", recurringNotification.Timezone)
+		assert.Equal(t, StatusActive, recurringNotification.Status)
+		assert.Nil(t, recurringNotification.EndsAt)
+	})
+
+	t.Run("Should return nil when recurring notification not found", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		recurringNotification, err := suite.repository.FindRecurringNotificationByID(1)
+		require.Nilf(t, err, "failed to find recurring notification: %v", err)
+
+		assert.Nil(t, recurringNotification)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestListRecurringNotifications() {
+	t := suite.T()
+
+	t.Run("Should list recurring notifications filtered by status", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		suite.createRecurringNotification(t, time.Now())
+		paused := suite.createRecurringNotification(t, time.Now())
+
+		_, err = suite.repository.PauseRecurringNotificationByID(paused)
+		require.Nilf(t, err, "failed to pause recurring notification: %v", err)
+
+		list, err := suite.repository.ListRecurringNotifications(&ListRecurringNotificationsFilter{Status: string(StatusPaused)})
+		require.Nilf(t, err, "failed to list recurring notifications: %v", err)
+		require.Len(t, list, 1)
+
+		assert.Equal(t, paused, list[0].Id)
+
+		list, err = suite.repository.ListRecurringNotifications(&ListRecurringNotificationsFilter{})
+		require.Nilf(t, err, "failed to list recurring notifications: %v", err)
+
+		assert.Len(t, list, 2)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestClaimDueRecurringNotifications() {
+	t := suite.T()
+
+	t.Run("Should claim series behind the horizon only once", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		materializedUntil := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+		until := time.Now().Add(24 * time.Hour).Truncate(time.Microsecond)
+		id := suite.createRecurringNotification(t, materializedUntil)
+		suite.createRecurringNotification(t, until.Add(time.Hour))
+
+		claimed, err := suite.repository.ClaimDueRecurringNotifications(until, 10, time.Minute)
+		require.Nilf(t, err, "failed to claim recurring notifications: %v", err)
+		require.Len(t, claimed, 1)
+
+		assert.Equal(t, id, claimed[0].Id)
+		assert.True(t, materializedUntil.Equal(claimed[0].MaterializedUntil))
+
+		claimed, err = suite.repository.ClaimDueRecurringNotifications(until, 10, time.Minute)
+		require.Nilf(t, err, "failed to claim recurring notifications: %v", err)
+
+		assert.Empty(t, claimed)
+	})
+
+	t.Run("Should claim a released series again", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		materializedUntil := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+		until := time.Now().Add(24 * time.Hour)
+		id := suite.createRecurringNotification(t, materializedUntil)
+
+		_, err = suite.repository.ClaimDueRecurringNotifications(until, 10, time.Minute)
+		require.Nilf(t, err, "failed to claim recurring notifications: %v", err)
+
+		released, err := suite.repository.ReleaseRecurringNotification(id)
+		require.Nilf(t, err, "failed to release recurring notification: %v", err)
+
+		claimed, err := suite.repository.ClaimDueRecurringNotifications(until, 10, time.Minute)
+		require.Nilf(t, err, "failed to claim recurring notifications: %v", err)
+
+		assert.True(t, released)
+		assert.Len(t, claimed, 1)
+	})
+
+	t.Run("Should claim a series again once its lease expires", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		until := time.Now().Add(24 * time.Hour)
+		suite.createRecurringNotification(t, time.Now().Add(-time.Hour))
+
+		_, err = suite.repository.ClaimDueRecurringNotifications(until, 10, time.Minute)
+		require.Nilf(t, err, "failed to claim recurring notifications: %v", err)
+
+		_, err = suite.db.Exec(`UPDATE recurring_notifications SET claimed_at = NOW() - INTERVAL '2 minutes'`)
+		require.Nilf(t, err, "failed to age claim: %v", err)
+
+		claimed, err := suite.repository.ClaimDueRecurringNotifications(until, 10, time.Minute)
+		require.Nilf(t, err, "failed to claim recurring notifications: %v", err)
+
+		assert.Len(t, claimed, 1)
+	})
+
+	t.Run("Should not claim paused series", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id := suite.createRecurringNotification(t, time.Now().Add(-time.Hour))
+
+		_, err = suite.repository.PauseRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to pause recurring notification: %v", err)
+
+		claimed, err := suite.repository.ClaimDueRecurringNotifications(time.Now().Add(24*time.Hour), 10, time.Minute)
+		require.Nilf(t, err, "failed to claim recurring notifications: %v", err)
+
+		assert.Empty(t, claimed)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestCreateOccurrences() {
+	t := suite.T()
+
+	newOccurrence := func(id int64) *notifications.CreateNotification {
+		return &notifications.CreateNotification{
+			Type:                    "sms",
+			Recipient:               "+5511999999999",
+			Message:                 "Hello",
+			ScheduledAt:             time.Now().Add(time.Hour),
+			RecurringNotificationId: &id,
+		}
+	}
+
+	t.Run("Should create the occurrences and advance the series", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		until := time.Now().Add(24 * time.Hour).Truncate(time.Microsecond)
+		id := suite.createRecurringNotification(t, time.Now().Add(-time.Hour))
+
+		claimed, err := suite.repository.ClaimDueRecurringNotifications(until, 10, time.Minute)
+		require.Nilf(t, err, "failed to claim recurring notifications: %v", err)
+		require.Len(t, claimed, 1)
+
+		ids, err := suite.repository.CreateOccurrences(claimed[0], until, []*notifications.CreateNotification{newOccurrence(id)})
+		require.Nilf(t, err, "failed to create occurrences: %v", err)
+
+		recurringNotification, err := suite.repository.FindRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to find recurring notification: %v", err)
+
+		assert.Len(t, ids, 1)
+		assert.True(t, until.Equal(recurringNotification.MaterializedUntil))
+	})
+
+	t.Run("Should create nothing when the series was paused since it was claimed", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		materializedUntil := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+		until := time.Now().Add(24 * time.Hour)
+		id := suite.createRecurringNotification(t, materializedUntil)
+
+		claimed, err := suite.repository.ClaimDueRecurringNotifications(until, 10, time.Minute)
+		require.Nilf(t, err, "failed to claim recurring notifications: %v", err)
+		require.Len(t, claimed, 1)
+
+		_, err = suite.repository.PauseRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to pause recurring notification: %v", err)
+
+		ids, err := suite.repository.CreateOccurrences(claimed[0], until, []*notifications.CreateNotification{newOccurrence(id)})
+		require.Nilf(t, err, "failed to create occurrences: %v", err)
+
+		var count int
+		err = suite.db.QueryRow(`SELECT COUNT(*) FROM notifications WHERE recurring_notification_id = $1`, id).Scan(&count)
+		require.Nilf(t, err, "failed to count notifications: %v", err)
+
+		recurringNotification, err := suite.repository.FindRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to find recurring notification: %v", err)
+
+		assert.Empty(t, ids)
+		assert.Equal(t, 0, count)
+		assert.True(t, materializedUntil.Equal(recurringNotification.MaterializedUntil))
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestTransitionRecurringNotification() {
+	t := suite.T()
+
+	t.Run("Should cancel the pending notifications of a paused series", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id := suite.createRecurringNotification(t, time.Now())
+
+		notificationRepository := notifications.NewPostgresRepository(suite.db)
+		notificationId, err := notificationRepository.CreateNotification(&notifications.CreateNotification{
+			Type:                    "sms",
+			Recipient:               "+5511999999999",
+			Message:                 "Hello",
+			ScheduledAt:             time.Now().Add(time.Hour),
+			RecurringNotificationId: &id,
+		})
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		found, err := suite.repository.PauseRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to pause recurring notification: %v", err)
+
+		recurringNotification, err := suite.repository.FindRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to find recurring notification: %v", err)
+
+		notification, err := notificationRepository.FindNotificationByID(notificationId)
+		require.Nilf(t, err, "failed to find notification: %v", err)
+
+		assert.True(t, found)
+		assert.Equal(t, StatusPaused, recurringNotification.Status)
+		assert.NotNil(t, recurringNotification.PausedAt)
+		assert.Equal(t, notifications.StatusCanceled, notification.Status)
+	})
+
+	t.Run("Should resume and end a paused series", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id := suite.createRecurringNotification(t, time.Now())
+
+		_, err = suite.repository.PauseRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to pause recurring notification: %v", err)
+
+		resumed, err := suite.repository.ResumeRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to resume recurring notification: %v", err)
+
+		ended, err := suite.repository.EndRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to end recurring notification: %v", err)
+
+		recurringNotification, err := suite.repository.FindRecurringNotificationByID(id)
+		require.Nilf(t, err, "failed to find recurring notification: %v", err)
+
+		assert.True(t, resumed)
+		assert.True(t, ended)
+		assert.Equal(t, StatusEnded, recurringNotification.Status)
+		assert.Nil(t, recurringNotification.PausedAt)
+		assert.NotNil(t, recurringNotification.EndedAt)
+	})
+
+	t.Run("Should return error when the transition is not allowed", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id := suite.createRecurringNotification(t, time.Now())
+
+		_, err = suite.repository.ResumeRecurringNotificationByID(id)
+
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+	})
+
+	t.Run("Should return false when recurring notification not found", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		found, err := suite.repository.PauseRecurringNotificationByID(1)
+		require.Nilf(t, err, "failed to pause recurring notification: %v", err)
+
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,91 @@
+package recurring
+
+import (
+	"errors"
+	"github.com/robfig/cron/v3"
+	"github.com/teambition/rrule-go"
+	"strings"
+	"time"
+)
+
+var ErrRruleOrCron = errors.New("exactly one of rrule or cron must be informed")
+
+// Schedule lists the occurrences of a series.
+type Schedule interface {
+	// Next returns the first occurrence after t, or the zero time when there is
+	// none.
+	Next(t time.Time) time.Time
+}
+
+type rruleSchedule struct {
+	rule   *rrule.RRule
+	endsAt *time.Time
+}
+
+type cronSchedule struct {
+	schedule cron.Schedule
+	location *time.Location
+	startsAt time.Time
+	endsAt   *time.Time
+}
+
+// ParseSchedule parses either an iCalendar RRULE (e.g. FREQ=MONTHLY;BYMONTHDAY=5)
+// or a five field cron expression, evaluated in timezone from startsAt until
+// endsAt, when informed.
+func ParseSchedule(rule, expression, timezone string, startsAt time.Time, endsAt *time.Time) (Schedule, error) {
+	if (rule == "") == (expression == "") {
+		return nil, ErrRruleOrCron
+	}
+
+	location, err := time.LoadLocation(timezone)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if expression != "" {
+		schedule, err := cron.ParseStandard(expression)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &cronSchedule{schedule: schedule, location: location, startsAt: startsAt, endsAt: endsAt}, nil
+	}
+
+	option, err := rrule.StrToROption(strings.TrimPrefix(rule, "RRULE:"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	option.Dtstart = startsAt.In(location)
+
+	parsed, err := rrule.NewRRule(*option)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rruleSchedule{rule: parsed, endsAt: endsAt}, nil
+}
+
+func (s *rruleSchedule) Next(t time.Time) time.Time {
+	return bounded(s.rule.After(t, false), s.endsAt)
+}
+
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	if t.Before(s.startsAt) {
+		t = s.startsAt.Add(-time.Nanosecond)
+	}
+
+	return bounded(s.schedule.Next(t.In(s.location)), s.endsAt)
+}
+
+func bounded(next time.Time, endsAt *time.Time) time.Time {
+	if endsAt != nil && next.After(*endsAt) {
+		return time.Time{}
+	}
+
+	return next
+}
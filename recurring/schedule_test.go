@@ -0,0 +1,68 @@
+package recurring
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseSchedule(t *testing.T) {
+	location, err := time.LoadLocation("America/Sao_Paulo")
+
+	require.Nilf(t, err, "failed to load location: %v", err)
+
+	startsAt := time.Date(2030, time.January, 1, 9, 0, 0, 0, location)
+	endsAt := time.Date(2030, time.March, 1, 0, 0, 0, 0, location)
+
+	testCases := []struct {
+		name       string
+		rrule      string
+		cron       string
+		endsAt     *time.Time
+		after      time.Time
+		expectedAt time.Time
+	}{
+		{"Should list the next occurrence of a rrule", "FREQ=MONTHLY;BYMONTHDAY=5", "", nil, startsAt, time.Date(2030, time.January, 5, 9, 0, 0, 0, location)},
+		{"Should accept the RRULE prefix", "RRULE:FREQ=MONTHLY;BYMONTHDAY=5", "", nil, time.Date(2030, time.January, 5, 9, 0, 0, 0, location), time.Date(2030, time.February, 5, 9, 0, 0, 0, location)},
+		{"Should stop a rrule at ends_at", "FREQ=MONTHLY;BYMONTHDAY=5", "", &endsAt, time.Date(2030, time.February, 5, 9, 0, 0, 0, location), time.Time{}},
+		{"Should list the next occurrence of a cron in the timezone", "", "0 9 * * MON", nil, startsAt, time.Date(2030, time.January, 7, 9, 0, 0, 0, location)},
+		{"Should not list cron occurrences before starts_at", "", "0 9 * * *", nil, time.Date(2029, time.December, 1, 0, 0, 0, 0, location), startsAt},
+		{"Should stop a cron at ends_at", "", "0 9 * * *", &endsAt, endsAt, time.Time{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.rrule, tc.cron, "America/Sao_Paulo", startsAt, tc.endsAt)
+
+			require.Nilf(t, err, "failed to parse schedule: %v", err)
+
+			assert.True(t, tc.expectedAt.Equal(schedule.Next(tc.after)), "expected %v, got %v", tc.expectedAt, schedule.Next(tc.after))
+		})
+	}
+}
+
+func TestInvalidParseSchedule(t *testing.T) {
+	startsAt := time.Date(2030, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		rrule    string
+		cron     string
+		timezone string
+	}{
+		{"Should fail when neither rrule nor cron is informed", "", "", "UTC"},
+		{"Should fail when both rrule and cron are informed", "FREQ=DAILY", "0 9 * * *", "UTC"},
+		{"Should fail when the rrule is invalid", "FREQ=SOMETIMES", "", "UTC"},
+		{"Should fail when the cron is invalid", "", "0 9 * *", "UTC"},
+		{"Should fail when the timezone is invalid", "FREQ=DAILY", "", "Mars/Olympus"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseSchedule(tc.rrule, tc.cron, tc.timezone, startsAt, nil)
+
+			assert.NotNil(t, err)
+		})
+	}
+}
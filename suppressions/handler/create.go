@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	"net/http"
+)
+
+var createSuppressionSchema = zog.Struct(zog.Schema{
+	"type":      zog.String().Trim().Required().OneOf(notifications.Types),
+	"recipient": zog.String().Min(3).Max(255).Required(),
+	"reason":    zog.String().Trim().Required().OneOf(suppressions.Reasons),
+	"note":      zog.Ptr(zog.String().Trim().Max(1024)),
+})
+
+var errInvalidBody = errors.New("invalid request body")
+
+type CreateHandler struct {
+	suppressionRepository suppressions.Repository
+}
+
+func NewCreateHandler(suppressionRepository suppressions.Repository) *CreateHandler {
+	return &CreateHandler{suppressionRepository: suppressionRepository}
+}
+
+func (h *CreateHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var createSuppression *suppressions.CreateSuppression
+	err := json.NewDecoder(r.Body).Decode(&createSuppression)
+
+	if err != nil || createSuppression == nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
+	validationErrors := createSuppressionSchema.Validate(createSuppression)
+
+	if validationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(validationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	recipient, err := notifications.NormalizeRecipient(createSuppression.Type, createSuppression.Recipient)
+
+	if err != nil {
+		httputil.UnprocessableEntityResponse(w, &httputil.UnprocessableEntityError{
+			Errors: []string{`The field "recipient" ` + err.Error()},
+		})
+		return
+	}
+
+	createSuppression.Recipient = recipient
+
+	id, err := h.suppressionRepository.CreateSuppression(createSuppression)
+
+	if err != nil {
+		if errors.Is(err, suppressions.ErrAlreadySuppressed) {
+			httputil.ConflictResponse(w, err)
+			return
+		}
+
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	suppression, err := h.suppressionRepository.FindSuppressionByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.CreatedResponse(w, suppression)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	"github.com/Tagliatti/magalu-challenge/suppressions/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuccessCreate(t *testing.T) {
+	t.Run("Should create a suppression of the normalized recipient", func(t *testing.T) {
+		body := `{"type":"sms","recipient":"+55 (11) 99999-9999","reason":"unsubscribed"}`
+
+		suppression := suppressions.Suppression{
+			Id:        1,
+			Type:      "sms",
+			Recipient: "+5511999999999",
+			Reason:    "unsubscribed",
+			CreatedAt: time.Now().UTC(),
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/suppressions", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateSuppression", &suppressions.CreateSuppression{
+			Type:      "sms",
+			Recipient: "+5511999999999",
+			Reason:    "unsubscribed",
+		}).Return(int64(1), nil)
+		repository.On("FindSuppressionByID", int64(1)).Return(&suppression, nil)
+
+		NewCreateHandler(repository).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(suppression)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestConflictOnCreate(t *testing.T) {
+	t.Run("Should return 409 when the recipient is already suppressed", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/suppressions", strings.NewReader(`{"type":"email","recipient":"test@example.com","reason":"bounced"}`))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateSuppression", &suppressions.CreateSuppression{
+			Type:      "email",
+			Recipient: "test@example.com",
+			Reason:    "bounced",
+		}).Return(int64(0), suppressions.ErrAlreadySuppressed)
+
+		NewCreateHandler(repository).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(suppressions.ErrAlreadySuppressed))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestInvalidRequestOnCreate(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		body                 string
+		expectedStatusCode   int
+		expectedBodyContains string
+	}{
+		{"Should return 400 when body is invalid", `invalid`, http.StatusBadRequest, errInvalidBody.Error()},
+		{"Should return 422 when type is invalid", `{"type":"fax","recipient":"test@example.com","reason":"manual"}`, http.StatusUnprocessableEntity, `\"type\"`},
+		{"Should return 422 when reason is invalid", `{"type":"email","recipient":"test@example.com","reason":"spam"}`, http.StatusUnprocessableEntity, `\"reason\"`},
+		{"Should return 422 when recipient is invalid for the type", `{"type":"email","recipient":"+5511999999999","reason":"manual"}`, http.StatusUnprocessableEntity, `\"recipient\" must be a valid e-mail address`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/suppressions", strings.NewReader(tc.body))
+
+			NewCreateHandler(mocks.NewRepository(t)).
+				Handler(response, request)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	"net/http"
+)
+
+type DeleteHandler struct {
+	suppressionRepository suppressions.Repository
+}
+
+func NewDeleteHandler(suppressionRepository suppressions.Repository) *DeleteHandler {
+	return &DeleteHandler{suppressionRepository: suppressionRepository}
+}
+
+func (h *DeleteHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	deleted, err := h.suppressionRepository.DeleteSuppressionByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !deleted {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.NoContentResponse(w)
+}
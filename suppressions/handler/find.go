@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	"net/http"
+)
+
+var errNotFound = errors.New("suppression not found")
+var errInvalidOrMissingId = errors.New("invalid or missing suppression id")
+
+type FindHandler struct {
+	suppressionRepository suppressions.Repository
+}
+
+func NewFindHandler(suppressionRepository suppressions.Repository) *FindHandler {
+	return &FindHandler{suppressionRepository: suppressionRepository}
+}
+
+func (h *FindHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	suppression, err := h.suppressionRepository.FindSuppressionByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if suppression == nil {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.OkResponse(w, suppression)
+}
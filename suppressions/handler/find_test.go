@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	"github.com/Tagliatti/magalu-challenge/suppressions/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	suppression := &suppressions.Suppression{Id: 1, Type: "email", Recipient: "test@example.com", Reason: "bounced"}
+
+	testCases := []struct {
+		name               string
+		suppression        *suppressions.Suppression
+		expectedStatusCode int
+		expectedBody       any
+	}{
+		{"Should find suppression successfully", suppression, http.StatusOK, suppression},
+		{"Should return 404 when suppression not found", nil, http.StatusNotFound, httputil.NewErrorMessage(errNotFound)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("GET", "/suppressions/1", nil)
+			request.SetPathValue("id", "1")
+
+			repository := mocks.NewRepository(t)
+			repository.On("FindSuppressionByID", int64(1)).Return(tc.suppression, nil)
+
+			NewFindHandler(repository).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(tc.expectedBody)
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+
+	t.Run("Should return 400 when id is invalid", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/suppressions/abc", nil)
+		request.SetPathValue("id", "abc")
+
+		NewFindHandler(mocks.NewRepository(t)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/Oudwins/zog"
+	"github.com/Oudwins/zog/zhttp"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/notifications"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	"net/http"
+)
+
+var listSuppressionsSchema = zog.Struct(zog.Schema{
+	"type":      zog.String().Trim().OneOf(notifications.Types),
+	"recipient": zog.String().Trim().Max(255),
+})
+
+type ListHandler struct {
+	suppressionRepository suppressions.Repository
+}
+
+func NewListHandler(suppressionRepository suppressions.Repository) *ListHandler {
+	return &ListHandler{suppressionRepository: suppressionRepository}
+}
+
+func (h *ListHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var filter suppressions.ListSuppressionsFilter
+
+	validationErrors := listSuppressionsSchema.Parse(zhttp.Request(r), &filter)
+
+	if validationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(validationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	if filter.Type != "" && filter.Recipient != "" {
+		recipient, err := notifications.NormalizeRecipient(filter.Type, filter.Recipient)
+
+		if err == nil {
+			filter.Recipient = recipient
+		}
+	}
+
+	list, err := h.suppressionRepository.ListSuppressions(&filter)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.OkResponse(w, list)
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/suppressions"
+	"github.com/Tagliatti/magalu-challenge/suppressions/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	t.Run("Should list suppressions filtered by the normalized recipient", func(t *testing.T) {
+		list := []*suppressions.Suppression{
+			{Id: 1, Type: "email", Recipient: "test@example.com", Reason: "complaint"},
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/suppressions?type=email&recipient=Test@Example.com", nil)
+
+		repository := mocks.NewRepository(t)
+		repository.On("ListSuppressions", &suppressions.ListSuppressionsFilter{Type: "email", Recipient: "test@example.com"}).Return(list, nil)
+
+		NewListHandler(repository).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(list)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+
+	t.Run("Should return 422 when type is invalid", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/suppressions?type=fax", nil)
+
+		NewListHandler(mocks.NewRepository(t)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+	})
+}
@@ -0,0 +1,321 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	suppressions "github.com/Tagliatti/magalu-challenge/suppressions"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// CreateSuppression provides a mock function with given fields: createSuppression
+func (_m *Repository) CreateSuppression(createSuppression *suppressions.CreateSuppression) (int64, error) {
+	ret := _m.Called(createSuppression)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSuppression")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*suppressions.CreateSuppression) (int64, error)); ok {
+		return rf(createSuppression)
+	}
+	if rf, ok := ret.Get(0).(func(*suppressions.CreateSuppression) int64); ok {
+		r0 = rf(createSuppression)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*suppressions.CreateSuppression) error); ok {
+		r1 = rf(createSuppression)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_CreateSuppression_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSuppression'
+type Repository_CreateSuppression_Call struct {
+	*mock.Call
+}
+
+// CreateSuppression is a helper method to define mock.On call
+//   - createSuppression *suppressions.CreateSuppression
+func (_e *Repository_Expecter) CreateSuppression(createSuppression interface{}) *Repository_CreateSuppression_Call {
+	return &Repository_CreateSuppression_Call{Call: _e.mock.On("CreateSuppression", createSuppression)}
+}
+
+func (_c *Repository_CreateSuppression_Call) Run(run func(createSuppression *suppressions.CreateSuppression)) *Repository_CreateSuppression_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*suppressions.CreateSuppression))
+	})
+	return _c
+}
+
+func (_c *Repository_CreateSuppression_Call) Return(_a0 int64, _a1 error) *Repository_CreateSuppression_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_CreateSuppression_Call) RunAndReturn(run func(*suppressions.CreateSuppression) (int64, error)) *Repository_CreateSuppression_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSuppressionByID provides a mock function with given fields: id
+func (_m *Repository) DeleteSuppressionByID(id int64) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSuppressionByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_DeleteSuppressionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSuppressionByID'
+type Repository_DeleteSuppressionByID_Call struct {
+	*mock.Call
+}
+
+// DeleteSuppressionByID is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) DeleteSuppressionByID(id interface{}) *Repository_DeleteSuppressionByID_Call {
+	return &Repository_DeleteSuppressionByID_Call{Call: _e.mock.On("DeleteSuppressionByID", id)}
+}
+
+func (_c *Repository_DeleteSuppressionByID_Call) Run(run func(id int64)) *Repository_DeleteSuppressionByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteSuppressionByID_Call) Return(_a0 bool, _a1 error) *Repository_DeleteSuppressionByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_DeleteSuppressionByID_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_DeleteSuppressionByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindSuppressed provides a mock function with given fields: keys
+func (_m *Repository) FindSuppressed(keys []suppressions.Key) (map[suppressions.Key]string, error) {
+	ret := _m.Called(keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSuppressed")
+	}
+
+	var r0 map[suppressions.Key]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]suppressions.Key) (map[suppressions.Key]string, error)); ok {
+		return rf(keys)
+	}
+	if rf, ok := ret.Get(0).(func([]suppressions.Key) map[suppressions.Key]string); ok {
+		r0 = rf(keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[suppressions.Key]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]suppressions.Key) error); ok {
+		r1 = rf(keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindSuppressed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSuppressed'
+type Repository_FindSuppressed_Call struct {
+	*mock.Call
+}
+
+// FindSuppressed is a helper method to define mock.On call
+//   - keys []suppressions.Key
+func (_e *Repository_Expecter) FindSuppressed(keys interface{}) *Repository_FindSuppressed_Call {
+	return &Repository_FindSuppressed_Call{Call: _e.mock.On("FindSuppressed", keys)}
+}
+
+func (_c *Repository_FindSuppressed_Call) Run(run func(keys []suppressions.Key)) *Repository_FindSuppressed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]suppressions.Key))
+	})
+	return _c
+}
+
+func (_c *Repository_FindSuppressed_Call) Return(_a0 map[suppressions.Key]string, _a1 error) *Repository_FindSuppressed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindSuppressed_Call) RunAndReturn(run func([]suppressions.Key) (map[suppressions.Key]string, error)) *Repository_FindSuppressed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindSuppressionByID provides a mock function with given fields: id
+func (_m *Repository) FindSuppressionByID(id int64) (*suppressions.Suppression, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSuppressionByID")
+	}
+
+	var r0 *suppressions.Suppression
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*suppressions.Suppression, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *suppressions.Suppression); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*suppressions.Suppression)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindSuppressionByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindSuppressionByID'
+type Repository_FindSuppressionByID_Call struct {
+	*mock.Call
+}
+
+// FindSuppressionByID is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) FindSuppressionByID(id interface{}) *Repository_FindSuppressionByID_Call {
+	return &Repository_FindSuppressionByID_Call{Call: _e.mock.On("FindSuppressionByID", id)}
+}
+
+func (_c *Repository_FindSuppressionByID_Call) Run(run func(id int64)) *Repository_FindSuppressionByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_FindSuppressionByID_Call) Return(_a0 *suppressions.Suppression, _a1 error) *Repository_FindSuppressionByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindSuppressionByID_Call) RunAndReturn(run func(int64) (*suppressions.Suppression, error)) *Repository_FindSuppressionByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSuppressions provides a mock function with given fields: filter
+func (_m *Repository) ListSuppressions(filter *suppressions.ListSuppressionsFilter) ([]*suppressions.Suppression, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSuppressions")
+	}
+
+	var r0 []*suppressions.Suppression
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*suppressions.ListSuppressionsFilter) ([]*suppressions.Suppression, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*suppressions.ListSuppressionsFilter) []*suppressions.Suppression); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*suppressions.Suppression)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*suppressions.ListSuppressionsFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListSuppressions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSuppressions'
+type Repository_ListSuppressions_Call struct {
+	*mock.Call
+}
+
+// ListSuppressions is a helper method to define mock.On call
+//   - filter *suppressions.ListSuppressionsFilter
+func (_e *Repository_Expecter) ListSuppressions(filter interface{}) *Repository_ListSuppressions_Call {
+	return &Repository_ListSuppressions_Call{Call: _e.mock.On("ListSuppressions", filter)}
+}
+
+func (_c *Repository_ListSuppressions_Call) Run(run func(filter *suppressions.ListSuppressionsFilter)) *Repository_ListSuppressions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*suppressions.ListSuppressionsFilter))
+	})
+	return _c
+}
+
+func (_c *Repository_ListSuppressions_Call) Return(_a0 []*suppressions.Suppression, _a1 error) *Repository_ListSuppressions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListSuppressions_Call) RunAndReturn(run func(*suppressions.ListSuppressionsFilter) ([]*suppressions.Suppression, error)) *Repository_ListSuppressions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,198 @@
+package suppressions
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+type Repository interface {
+	CreateSuppression(createSuppression *CreateSuppression) (int64, error)
+	FindSuppressionByID(id int64) (*Suppression, error)
+	ListSuppressions(filter *ListSuppressionsFilter) ([]*Suppression, error)
+	FindSuppressed(keys []Key) (map[Key]string, error)
+	DeleteSuppressionByID(id int64) (bool, error)
+}
+
+const suppressionColumns = `id, type, recipient, reason, note, created_at`
+
+const uniqueViolation = "23505"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) CreateSuppression(createSuppression *CreateSuppression) (int64, error) {
+	var id int64
+
+	err := r.db.QueryRow(`INSERT INTO suppressions (type, recipient, reason, note) VALUES ($1, $2, $3, $4) RETURNING id`,
+		createSuppression.Type,
+		createSuppression.Recipient,
+		createSuppression.Reason,
+		createSuppression.Note,
+	).Scan(&id)
+
+	if err != nil {
+		var pqErr *pq.Error
+
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return 0, ErrAlreadySuppressed
+		}
+
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *PostgresRepository) FindSuppressionByID(id int64) (*Suppression, error) {
+	row := r.db.QueryRow(`SELECT `+suppressionColumns+` FROM suppressions WHERE id = $1`, id)
+	suppression, err := scanSuppression(row)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return suppression, nil
+}
+
+func (r *PostgresRepository) ListSuppressions(filter *ListSuppressionsFilter) ([]*Suppression, error) {
+	conditions := make([]string, 0)
+	args := make([]any, 0)
+
+	where := func(condition string, value any) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Type != "" {
+		where("type = $%d", filter.Type)
+	}
+
+	if filter.Recipient != "" {
+		where("recipient = $%d", filter.Recipient)
+	}
+
+	query := `SELECT ` + suppressionColumns + ` FROM suppressions`
+
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := r.db.Query(query+` ORDER BY id DESC`, args...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := make([]*Suppression, 0)
+
+	for rows.Next() {
+		suppression, err := scanSuppression(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, suppression)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// FindSuppressed returns, for each of the keys that is suppressed, the reason of
+// its suppression.
+func (r *PostgresRepository) FindSuppressed(keys []Key) (map[Key]string, error) {
+	types := make([]string, len(keys))
+	recipients := make([]string, len(keys))
+
+	for i, key := range keys {
+		types[i] = key.Type
+		recipients[i] = key.Recipient
+	}
+
+	rows, err := r.db.Query(`
+		SELECT s.type, s.recipient, s.reason
+		FROM suppressions s
+		JOIN unnest($1::notification_type[], $2::varchar[]) AS k (type, recipient) ON k.type = s.type AND k.recipient = s.recipient`,
+		pq.Array(types),
+		pq.Array(recipients),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppressed := make(map[Key]string)
+
+	for rows.Next() {
+		var key Key
+		var reason string
+
+		if err := rows.Scan(&key.Type, &key.Recipient, &reason); err != nil {
+			return nil, err
+		}
+
+		suppressed[key] = reason
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suppressed, nil
+}
+
+func (r *PostgresRepository) DeleteSuppressionByID(id int64) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM suppressions WHERE id = $1`, id)
+
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func scanSuppression(row rowScanner) (*Suppression, error) {
+	var suppression Suppression
+	err := row.Scan(
+		&suppression.Id,
+		&suppression.Type,
+		&suppression.Recipient,
+		&suppression.Reason,
+		&suppression.Note,
+		&suppression.CreatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &suppression, nil
+}
@@ -0,0 +1,136 @@
+package suppressions
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Tagliatti/magalu-challenge/database"
+	"github.com/Tagliatti/magalu-challenge/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PostgresRepositoryTestSuite struct {
+	suite.Suite
+	pgContainer *testhelpers.PostgresContainer
+	repository  *PostgresRepository
+	db          *sql.DB
+	ctx         context.Context
+}
+
+func (suite *PostgresRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	pgContainer, err := testhelpers.NewPostgresContainer(suite.ctx)
+	require.Nil(suite.T(), err, "failed to start postgres container: %v", err)
+
+	suite.pgContainer = pgContainer
+
+	db, err := database.ConnectTest(pgContainer.ConnectionString)
+	require.Nil(suite.T(), err, "failed to connect to database: %v", err)
+
+	suite.db = db
+	suite.repository = NewPostgresRepository(db)
+}
+
+func (suite *PostgresRepositoryTestSuite) TearDownSuite() {
+	if err := suite.pgContainer.Terminate(suite.ctx); err != nil {
+		suite.T().Fatalf("failed to terminate pgContainer: %s", err)
+	}
+}
+
+func TestPostgresRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PostgresRepositoryTestSuite))
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessCreateSuppression() {
+	t := suite.T()
+
+	t.Run("Should create suppression successfully", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		note := "Pediu para sair pelo SAC"
+
+		id, err := suite.repository.CreateSuppression(&CreateSuppression{
+			Type:      "email",
+			Recipient: "test@example.com",
+			Reason:    "unsubscribed",
+			Note:      &note,
+		})
+		require.Nilf(t, err, "failed to create suppression: %v", err)
+
+		suppression, err := suite.repository.FindSuppressionByID(id)
+		require.Nilf(t, err, "failed to find suppression: %v", err)
+		require.NotNil(t, suppression)
+
+		assert.Equal(t, "email", suppression.Type)
+		assert.Equal(t, "test@example.com", suppression.Recipient)
+		assert.Equal(t, "unsubscribed", suppression.Reason)
+		assert.Equal(t, &note, suppression.Note)
+	})
+
+	t.Run("Should return error when the recipient is already suppressed for the type", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		createSuppression := &CreateSuppression{Type: "sms", Recipient: "+5511999999999", Reason: "manual"}
+
+		_, err = suite.repository.CreateSuppression(createSuppression)
+		require.Nilf(t, err, "failed to create suppression: %v", err)
+
+		_, err = suite.repository.CreateSuppression(createSuppression)
+
+		assert.ErrorIs(t, err, ErrAlreadySuppressed)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestFindSuppressed() {
+	t := suite.T()
+
+	t.Run("Should return the reason of the suppressed keys only", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		_, err = suite.repository.CreateSuppression(&CreateSuppression{Type: "sms", Recipient: "+5511999999999", Reason: "complaint"})
+		require.Nilf(t, err, "failed to create suppression: %v", err)
+
+		suppressed, err := suite.repository.FindSuppressed([]Key{
+			{Type: "sms", Recipient: "+5511999999999"},
+			{Type: "whatsapp", Recipient: "+5511999999999"},
+			{Type: "email", Recipient: "test@example.com"},
+		})
+		require.Nilf(t, err, "failed to find suppressed: %v", err)
+
+		assert.Equal(t, map[Key]string{{Type: "sms", Recipient: "+5511999999999"}: "complaint"}, suppressed)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestListAndDeleteSuppressions() {
+	t := suite.T()
+
+	t.Run("Should filter suppressions and delete them", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateSuppression(&CreateSuppression{Type: "email", Recipient: "test@example.com", Reason: "bounced"})
+		require.Nilf(t, err, "failed to create suppression: %v", err)
+
+		_, err = suite.repository.CreateSuppression(&CreateSuppression{Type: "sms", Recipient: "+5511999999999", Reason: "manual"})
+		require.Nilf(t, err, "failed to create suppression: %v", err)
+
+		list, err := suite.repository.ListSuppressions(&ListSuppressionsFilter{Type: "email"})
+		require.Nilf(t, err, "failed to list suppressions: %v", err)
+		require.Len(t, list, 1)
+		assert.Equal(t, id, list[0].Id)
+
+		deleted, err := suite.repository.DeleteSuppressionByID(id)
+		require.Nilf(t, err, "failed to delete suppression: %v", err)
+		assert.True(t, deleted)
+
+		deleted, err = suite.repository.DeleteSuppressionByID(id)
+		require.Nilf(t, err, "failed to delete suppression: %v", err)
+		assert.False(t, deleted)
+	})
+}
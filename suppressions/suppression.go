@@ -0,0 +1,37 @@
+package suppressions
+
+import (
+	"errors"
+	"time"
+)
+
+var Reasons = []string{"unsubscribed", "bounced", "complaint", "manual"}
+
+var ErrAlreadySuppressed = errors.New("recipient is already suppressed for this type")
+
+type Suppression struct {
+	Id        int64     `json:"id"`
+	Type      string    `json:"type"`
+	Recipient string    `json:"recipient"`
+	Reason    string    `json:"reason"`
+	Note      *string   `json:"note"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateSuppression struct {
+	Type      string  `json:"type"`
+	Recipient string  `json:"recipient"`
+	Reason    string  `json:"reason"`
+	Note      *string `json:"note"`
+}
+
+type ListSuppressionsFilter struct {
+	Type      string `zog:"type"`
+	Recipient string `zog:"recipient"`
+}
+
+// Key identifies the suppression of a normalized recipient on one channel.
+type Key struct {
+	Type      string
+	Recipient string
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var variableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var localizationSchema = zog.Struct(zog.Schema{
+	"subject":  zog.Ptr(zog.String().Max(998)),
+	"body":     zog.String().Required().Max(4096),
+	"htmlBody": zog.Ptr(zog.String().Max(65536)),
+})
+
+var templateContentSchema = localizationSchema.Extend(zog.Schema{
+	"variables": zog.Slice(zog.String().Match(variableNameRegex, zog.Message("must contain only letters, digits and underscores"))),
+})
+
+var createTemplateSchema = templateContentSchema.Extend(zog.Schema{
+	"name": zog.String().Trim().Required().Max(255),
+})
+
+var errInvalidBody = errors.New("invalid request body")
+
+type CreateHandler struct {
+	templateRepository templates.Repository
+}
+
+func NewCreateHandler(templateRepository templates.Repository) *CreateHandler {
+	return &CreateHandler{templateRepository: templateRepository}
+}
+
+func (h *CreateHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var createTemplate *templates.CreateTemplate
+	err := json.NewDecoder(r.Body).Decode(&createTemplate)
+
+	if err != nil || createTemplate == nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
+	validationErrors := createTemplateSchema.Validate(createTemplate)
+
+	if validationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(validationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	if unprocessableEntityError := checkSyntax("", createTemplate.Subject, createTemplate.Body, createTemplate.HtmlBody); unprocessableEntityError != nil {
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	locales, unprocessableEntityError := validateLocales(createTemplate.Locales)
+
+	if unprocessableEntityError != nil {
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	createTemplate.Locales = locales
+
+	id, err := h.templateRepository.CreateTemplate(createTemplate)
+
+	if err != nil {
+		if errors.Is(err, templates.ErrTemplateNameTaken) {
+			httputil.ConflictResponse(w, err)
+			return
+		}
+
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	template, err := h.templateRepository.FindTemplateByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.CreatedResponse(w, template)
+}
+
+func checkSyntax(prefix string, subject *string, body string, htmlBody *string) *httputil.UnprocessableEntityError {
+	var syntaxError *templates.SyntaxError
+
+	if err := templates.Check(subject, body, htmlBody); errors.As(err, &syntaxError) {
+		return &httputil.UnprocessableEntityError{
+			Errors: []string{`The field "` + prefix + syntaxError.Field + `" is not a valid template: ` + syntaxError.Err.Error()},
+		}
+	}
+
+	return nil
+}
+
+// validateLocales checks every localized variant like the default content,
+// returning the variants keyed by their canonical locale.
+func validateLocales(locales templates.Locales) (templates.Locales, *httputil.UnprocessableEntityError) {
+	if locales == nil {
+		return nil, nil
+	}
+
+	canonical := make(templates.Locales, len(locales))
+
+	for locale, localization := range locales {
+		if !templates.LocaleRegex.MatchString(locale) {
+			return nil, &httputil.UnprocessableEntityError{Errors: []string{`The field "locales" has an invalid locale "` + locale + `"`}}
+		}
+
+		locale = templates.CanonicalLocale(locale)
+		prefix := "locales." + locale + "."
+
+		if _, ok := canonical[locale]; ok {
+			return nil, &httputil.UnprocessableEntityError{Errors: []string{`The field "locales" has the locale "` + locale + `" more than once`}}
+		}
+
+		if localization == nil {
+			return nil, &httputil.UnprocessableEntityError{Errors: []string{`The field "` + prefix + `body" is required`}}
+		}
+
+		validationErrors := localizationSchema.Validate(localization)
+
+		if validationErrors != nil {
+			unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(validationErrors)
+
+			for i, message := range unprocessableEntityError.Errors {
+				unprocessableEntityError.Errors[i] = strings.Replace(message, `The field "`, `The field "`+prefix, 1)
+			}
+
+			return nil, unprocessableEntityError
+		}
+
+		if unprocessableEntityError := checkSyntax(prefix, localization.Subject, localization.Body, localization.HtmlBody); unprocessableEntityError != nil {
+			return nil, unprocessableEntityError
+		}
+
+		canonical[locale] = localization
+	}
+
+	return canonical, nil
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuccessCreate(t *testing.T) {
+	t.Run("Should create a template successfully", func(t *testing.T) {
+		body := `{"name":"order-shipped","subject":"Pedido {{.order}}","body":"Seu pedido {{.order}} saiu para entrega.","variables":["order"]}`
+
+		var createTemplate templates.CreateTemplate
+
+		err := json.Unmarshal([]byte(body), &createTemplate)
+
+		require.Nilf(t, err, "Failed to unmarshal JSON: %v", err)
+
+		template := templates.Template{
+			Id:        1,
+			Name:      createTemplate.Name,
+			Version:   1,
+			Subject:   createTemplate.Subject,
+			Body:      createTemplate.Body,
+			Variables: createTemplate.Variables,
+			CreatedAt: time.Now().UTC(),
+			UpdatedAt: time.Now().UTC(),
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/templates", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateTemplate", &createTemplate).Return(int64(1), nil)
+		repository.On("FindTemplateByID", int64(1)).Return(&template, nil)
+
+		NewCreateHandler(repository).
+			Handler(response, request)
+
+		expectedStatusCode := http.StatusCreated
+		expectedBody, err := json.Marshal(template)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, expectedStatusCode, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestConflictOnCreate(t *testing.T) {
+	t.Run("Should return 409 when the name is already taken", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/templates", strings.NewReader(`{"name":"order-shipped","body":"Hello"}`))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateTemplate", &templates.CreateTemplate{Name: "order-shipped", Body: "Hello"}).Return(int64(0), templates.ErrTemplateNameTaken)
+
+		NewCreateHandler(repository).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(httputil.NewErrorMessage(templates.ErrTemplateNameTaken))
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
+
+func TestInvalidRequestOnCreate(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		body                 string
+		expectedStatusCode   int
+		expectedBodyContains string
+	}{
+		{"Should return 400 when body is invalid", `invalid`, http.StatusBadRequest, errInvalidBody.Error()},
+		{"Should return 422 when name is missing", `{"body":"Hello"}`, http.StatusUnprocessableEntity, `\"name\"`},
+		{"Should return 422 when body is missing", `{"name":"order-shipped"}`, http.StatusUnprocessableEntity, `\"body\"`},
+		{"Should return 422 when a variable name is invalid", `{"name":"order-shipped","body":"Hello","variables":["first name"]}`, http.StatusUnprocessableEntity, `\"variables`},
+		{"Should return 422 when body is not a valid template", `{"name":"order-shipped","body":"Olá, {{.name"}`, http.StatusUnprocessableEntity, `\"body\" is not a valid template`},
+		{"Should return 422 when html body is not a valid template", `{"name":"order-shipped","body":"Hello","html_body":"{{end}}"}`, http.StatusUnprocessableEntity, `\"html_body\" is not a valid template`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/templates", strings.NewReader(tc.body))
+
+			repository := mocks.NewRepository(t)
+
+			NewCreateHandler(repository).
+				Handler(response, request)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
+
+func TestLocalesOnCreate(t *testing.T) {
+	t.Run("Should store locales by their canonical form", func(t *testing.T) {
+		body := `{"name":"order-shipped","body":"Hello","locales":{"pt-br":{"body":"Olá"},"es":{"body":"Hola"}}}`
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/templates", strings.NewReader(body))
+
+		repository := mocks.NewRepository(t)
+		repository.On("CreateTemplate", &templates.CreateTemplate{
+			Name: "order-shipped",
+			Body: "Hello",
+			Locales: templates.Locales{
+				"pt-BR": {Body: "Olá"},
+				"es":    {Body: "Hola"},
+			},
+		}).Return(int64(1), nil)
+		repository.On("FindTemplateByID", int64(1)).Return(&templates.Template{Id: 1}, nil)
+
+		NewCreateHandler(repository).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusCreated, response.Code)
+	})
+
+	testCases := []struct {
+		name                 string
+		locales              string
+		expectedBodyContains string
+	}{
+		{"Should return 422 when locale is invalid", `{"portuguese":{"body":"Olá"}}`, `invalid locale \"portuguese\"`},
+		{"Should return 422 when locale is repeated", `{"pt-BR":{"body":"Olá"},"pt-br":{"body":"Oi"}}`, `\"pt-BR\" more than once`},
+		{"Should return 422 when localized body is missing", `{"es":{"subject":"Hola"}}`, `\"locales.es.body\"`},
+		{"Should return 422 when localized body is not a valid template", `{"es":{"body":"{{.name"}}`, `\"locales.es.body\" is not a valid template`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/templates", strings.NewReader(`{"name":"order-shipped","body":"Hello","locales":`+tc.locales+`}`))
+
+			NewCreateHandler(mocks.NewRepository(t)).
+				Handler(response, request)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+			assert.Contains(t, response.Body.String(), tc.expectedBodyContains)
+		})
+	}
+}
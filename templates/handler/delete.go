@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"net/http"
+)
+
+var errTemplateInUse = errors.New("template is referenced by notifications and can not be deleted")
+
+type DeleteHandler struct {
+	templateRepository templates.Repository
+}
+
+func NewDeleteHandler(templateRepository templates.Repository) *DeleteHandler {
+	return &DeleteHandler{templateRepository: templateRepository}
+}
+
+func (h *DeleteHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	deleted, err := h.templateRepository.DeleteTemplateByID(id)
+
+	if err != nil {
+		if errors.Is(err, templates.ErrTemplateInUse) {
+			httputil.ConflictResponse(w, errTemplateInUse)
+			return
+		}
+
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !deleted {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.NoContentResponse(w)
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDelete(t *testing.T) {
+	testCases := []struct {
+		name               string
+		deleted            bool
+		err                error
+		expectedStatusCode int
+		expectedBody       error
+	}{
+		{"Should delete template successfully", true, nil, http.StatusNoContent, nil},
+		{"Should return 404 when template not found", false, nil, http.StatusNotFound, errNotFound},
+		{"Should return 409 when template is in use", false, templates.ErrTemplateInUse, http.StatusConflict, errTemplateInUse},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("DELETE", "/templates/1", nil)
+			request.SetPathValue("id", "1")
+
+			repository := mocks.NewRepository(t)
+			repository.On("DeleteTemplateByID", int64(1)).Return(tc.deleted, tc.err)
+
+			NewDeleteHandler(repository).
+				Handler(response, request)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+
+			if tc.expectedBody == nil {
+				assert.Empty(t, response.Body.String())
+				return
+			}
+
+			expectedBody, err := json.Marshal(httputil.NewErrorMessage(tc.expectedBody))
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+}
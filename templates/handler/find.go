@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"errors"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"net/http"
+)
+
+var errNotFound = errors.New("template not found")
+var errInvalidOrMissingId = errors.New("invalid or missing template id")
+var errInvalidOrMissingVersion = errors.New("invalid or missing template version")
+
+type FindHandler struct {
+	templateRepository templates.Repository
+}
+
+func NewFindHandler(templateRepository templates.Repository) *FindHandler {
+	return &FindHandler{templateRepository: templateRepository}
+}
+
+func (h *FindHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	template, err := h.templateRepository.FindTemplateByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if template == nil {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.OkResponse(w, template)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	template := &templates.Template{Id: 1, Name: "order-shipped", Version: 2, Body: "Hello", Variables: []string{}}
+
+	testCases := []struct {
+		name               string
+		id                 string
+		template           *templates.Template
+		expectedStatusCode int
+		expectedBody       any
+	}{
+		{"Should return the current version of the template", "1", template, http.StatusOK, template},
+		{"Should return 404 when template not found", "1", nil, http.StatusNotFound, httputil.NewErrorMessage(errNotFound)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			request := httptest.NewRequest("GET", "/templates/"+tc.id, nil)
+			request.SetPathValue("id", tc.id)
+
+			repository := mocks.NewRepository(t)
+			repository.On("FindTemplateByID", int64(1)).Return(tc.template, nil)
+
+			NewFindHandler(repository).
+				Handler(response, request)
+
+			expectedBody, err := json.Marshal(tc.expectedBody)
+
+			require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+			assert.Equal(t, tc.expectedStatusCode, response.Code)
+			assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+		})
+	}
+
+	t.Run("Should return 400 when id is invalid", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/templates/invalid-id", nil)
+		request.SetPathValue("id", "invalid-id")
+
+		NewFindHandler(mocks.NewRepository(t)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), errInvalidOrMissingId.Error())
+	})
+}
+
+func TestFindVersion(t *testing.T) {
+	t.Run("Should return the requested version of the template", func(t *testing.T) {
+		template := &templates.Template{Id: 1, Name: "order-shipped", Version: 1, Body: "Hello", Variables: []string{}}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/templates/1/versions/1", nil)
+		request.SetPathValue("id", "1")
+		request.SetPathValue("version", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("FindTemplateVersion", int64(1), 1).Return(template, nil)
+
+		NewVersionHandler(repository).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(template)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+
+	t.Run("Should return 400 when version is invalid", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/templates/1/versions/0", nil)
+		request.SetPathValue("id", "1")
+		request.SetPathValue("version", "0")
+
+		NewVersionHandler(mocks.NewRepository(t)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), errInvalidOrMissingVersion.Error())
+	})
+}
@@ -0,0 +1,26 @@
+package handler
+
+import (
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"net/http"
+)
+
+type ListHandler struct {
+	templateRepository templates.Repository
+}
+
+func NewListHandler(templateRepository templates.Repository) *ListHandler {
+	return &ListHandler{templateRepository: templateRepository}
+}
+
+func (h *ListHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	list, err := h.templateRepository.ListTemplates()
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.OkResponse(w, list)
+}
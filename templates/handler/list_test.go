@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	t.Run("Should list templates", func(t *testing.T) {
+		list := []*templates.Template{
+			{Id: 1, Name: "order-shipped", Version: 1, Body: "Hello", Variables: []string{}},
+		}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("GET", "/templates", nil)
+
+		repository := mocks.NewRepository(t)
+		repository.On("ListTemplates").Return(list, nil)
+
+		NewListHandler(repository).
+			Handler(response, request)
+
+		expectedBody, err := json.Marshal(list)
+
+		require.Nilf(t, err, "Failed to marshal JSON: %v", err)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, string(expectedBody), strings.Trim(response.Body.String(), "\n"))
+	})
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"net/http"
+)
+
+type UpdateHandler struct {
+	templateRepository templates.Repository
+}
+
+func NewUpdateHandler(templateRepository templates.Repository) *UpdateHandler {
+	return &UpdateHandler{templateRepository: templateRepository}
+}
+
+func (h *UpdateHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	var id int64
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	var updateTemplate *templates.UpdateTemplate
+	err := json.NewDecoder(r.Body).Decode(&updateTemplate)
+
+	if err != nil || updateTemplate == nil {
+		httputil.BadRequestResponse(w, errInvalidBody)
+		return
+	}
+
+	bodyValidationErrors := templateContentSchema.Validate(updateTemplate)
+
+	if bodyValidationErrors != nil {
+		unprocessableEntityError := httputil.NewUnprocessableEntityErrorFromZog(bodyValidationErrors)
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	if unprocessableEntityError := checkSyntax("", updateTemplate.Subject, updateTemplate.Body, updateTemplate.HtmlBody); unprocessableEntityError != nil {
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	locales, unprocessableEntityError := validateLocales(updateTemplate.Locales)
+
+	if unprocessableEntityError != nil {
+		httputil.UnprocessableEntityResponse(w, unprocessableEntityError)
+		return
+	}
+
+	updateTemplate.Locales = locales
+
+	found, err := h.templateRepository.UpdateTemplateByID(id, updateTemplate)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if !found {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	template, err := h.templateRepository.FindTemplateByID(id)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	httputil.OkResponse(w, template)
+}
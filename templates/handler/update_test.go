@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"github.com/Tagliatti/magalu-challenge/templates/mocks"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUpdate(t *testing.T) {
+	t.Run("Should create a new version of the template", func(t *testing.T) {
+		template := &templates.Template{Id: 1, Name: "order-shipped", Version: 2, Body: "Olá, {{.name}}", Variables: []string{"name"}}
+
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PUT", "/templates/1", strings.NewReader(`{"body":"Olá, {{.name}}","variables":["name"]}`))
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("UpdateTemplateByID", int64(1), &templates.UpdateTemplate{Body: "Olá, {{.name}}", Variables: []string{"name"}}).Return(true, nil)
+		repository.On("FindTemplateByID", int64(1)).Return(template, nil)
+
+		NewUpdateHandler(repository).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"version":2`)
+	})
+
+	t.Run("Should return 404 when template not found", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PUT", "/templates/1", strings.NewReader(`{"body":"Hello"}`))
+		request.SetPathValue("id", "1")
+
+		repository := mocks.NewRepository(t)
+		repository.On("UpdateTemplateByID", int64(1), &templates.UpdateTemplate{Body: "Hello"}).Return(false, nil)
+
+		NewUpdateHandler(repository).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("Should return 422 when body is not a valid template", func(t *testing.T) {
+		response := httptest.NewRecorder()
+		request := httptest.NewRequest("PUT", "/templates/1", strings.NewReader(`{"body":"{{if}}"}`))
+		request.SetPathValue("id", "1")
+
+		NewUpdateHandler(mocks.NewRepository(t)).
+			Handler(response, request)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Contains(t, response.Body.String(), `\"body\" is not a valid template`)
+	})
+}
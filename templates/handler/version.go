@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"github.com/Oudwins/zog"
+	"github.com/Tagliatti/magalu-challenge/httputil"
+	"github.com/Tagliatti/magalu-challenge/templates"
+	"net/http"
+)
+
+type VersionHandler struct {
+	templateRepository templates.Repository
+}
+
+func NewVersionHandler(templateRepository templates.Repository) *VersionHandler {
+	return &VersionHandler{templateRepository: templateRepository}
+}
+
+func (h *VersionHandler) Handler(w http.ResponseWriter, r *http.Request) {
+	var id int64
+	var version int
+
+	validationErrors := zog.Int64().Required().Parse(r.PathValue("id"), &id)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingId)
+		return
+	}
+
+	validationErrors = zog.Int().Required().GT(0).Parse(r.PathValue("version"), &version)
+
+	if validationErrors != nil {
+		httputil.BadRequestResponse(w, errInvalidOrMissingVersion)
+		return
+	}
+
+	template, err := h.templateRepository.FindTemplateVersion(id, version)
+
+	if err != nil {
+		httputil.InternalServerErrorResponse(w, err)
+		return
+	}
+
+	if template == nil {
+		httputil.NotFoundResponse(w, errNotFound)
+		return
+	}
+
+	httputil.OkResponse(w, template)
+}
@@ -0,0 +1,80 @@
+package templates
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var LocaleRegex = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z]{2})?$`)
+
+type Localization struct {
+	Subject  *string `json:"subject"`
+	Body     string  `json:"body"`
+	HtmlBody *string `json:"html_body"`
+}
+
+// Locales maps a locale such as "pt-BR" or "es" to the content of the template in
+// that language, stored as JSON.
+type Locales map[string]*Localization
+
+func (l Locales) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(l)
+}
+
+func (l *Locales) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		*l = Locales{}
+		return nil
+	case []byte:
+		return json.Unmarshal(src, l)
+	case string:
+		return json.Unmarshal([]byte(src), l)
+	}
+
+	return fmt.Errorf("cannot scan %T into Locales", src)
+}
+
+// CanonicalLocale formats a locale as language in lower case and region in upper
+// case, e.g. "pt-br" becomes "pt-BR".
+func CanonicalLocale(locale string) string {
+	language, region, found := strings.Cut(locale, "-")
+
+	if !found {
+		return strings.ToLower(language)
+	}
+
+	return strings.ToLower(language) + "-" + strings.ToUpper(region)
+}
+
+// Localized returns the template with the content of the best variant for locale:
+// the exact locale, then its language alone (pt-BR falls back to pt), then the
+// default content.
+func (t *Template) Localized(locale string) *Template {
+	if locale == "" || len(t.Locales) == 0 {
+		return t
+	}
+
+	locale = CanonicalLocale(locale)
+	language, _, _ := strings.Cut(locale, "-")
+
+	for _, candidate := range []string{locale, language} {
+		if localization, ok := t.Locales[candidate]; ok {
+			localized := *t
+			localized.Subject = localization.Subject
+			localized.Body = localization.Body
+			localized.HtmlBody = localization.HtmlBody
+
+			return &localized
+		}
+	}
+
+	return t
+}
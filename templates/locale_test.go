@@ -0,0 +1,42 @@
+package templates
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLocalized(t *testing.T) {
+	template := &Template{
+		Body: "Hello, {{.name}}",
+		Locales: Locales{
+			"pt":    {Body: "Olá, {{.name}}"},
+			"pt-PT": {Body: "Olá, {{.name}}!"},
+			"es":    {Body: "Hola, {{.name}}"},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		locale       string
+		expectedBody string
+	}{
+		{"Should use the default content without locale", "", "Hello, {{.name}}"},
+		{"Should use the exact locale", "pt-PT", "Olá, {{.name}}!"},
+		{"Should match the locale regardless of case", "PT-pt", "Olá, {{.name}}!"},
+		{"Should fall back to the language", "pt-BR", "Olá, {{.name}}"},
+		{"Should fall back to the language of a regional locale", "es-MX", "Hola, {{.name}}"},
+		{"Should fall back to the default content", "en-US", "Hello, {{.name}}"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedBody, template.Localized(tc.locale).Body)
+		})
+	}
+}
+
+func TestCanonicalLocale(t *testing.T) {
+	assert.Equal(t, "pt-BR", CanonicalLocale("pt-br"))
+	assert.Equal(t, "en-US", CanonicalLocale("EN-us"))
+	assert.Equal(t, "es", CanonicalLocale("ES"))
+}
@@ -0,0 +1,378 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	templates "github.com/Tagliatti/magalu-challenge/templates"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+type Repository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Repository) EXPECT() *Repository_Expecter {
+	return &Repository_Expecter{mock: &_m.Mock}
+}
+
+// CreateTemplate provides a mock function with given fields: createTemplate
+func (_m *Repository) CreateTemplate(createTemplate *templates.CreateTemplate) (int64, error) {
+	ret := _m.Called(createTemplate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTemplate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*templates.CreateTemplate) (int64, error)); ok {
+		return rf(createTemplate)
+	}
+	if rf, ok := ret.Get(0).(func(*templates.CreateTemplate) int64); ok {
+		r0 = rf(createTemplate)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*templates.CreateTemplate) error); ok {
+		r1 = rf(createTemplate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_CreateTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTemplate'
+type Repository_CreateTemplate_Call struct {
+	*mock.Call
+}
+
+// CreateTemplate is a helper method to define mock.On call
+//   - createTemplate *templates.CreateTemplate
+func (_e *Repository_Expecter) CreateTemplate(createTemplate interface{}) *Repository_CreateTemplate_Call {
+	return &Repository_CreateTemplate_Call{Call: _e.mock.On("CreateTemplate", createTemplate)}
+}
+
+func (_c *Repository_CreateTemplate_Call) Run(run func(createTemplate *templates.CreateTemplate)) *Repository_CreateTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*templates.CreateTemplate))
+	})
+	return _c
+}
+
+func (_c *Repository_CreateTemplate_Call) Return(_a0 int64, _a1 error) *Repository_CreateTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_CreateTemplate_Call) RunAndReturn(run func(*templates.CreateTemplate) (int64, error)) *Repository_CreateTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTemplateByID provides a mock function with given fields: id
+func (_m *Repository) DeleteTemplateByID(id int64) (bool, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTemplateByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) bool); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_DeleteTemplateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTemplateByID'
+type Repository_DeleteTemplateByID_Call struct {
+	*mock.Call
+}
+
+// DeleteTemplateByID is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) DeleteTemplateByID(id interface{}) *Repository_DeleteTemplateByID_Call {
+	return &Repository_DeleteTemplateByID_Call{Call: _e.mock.On("DeleteTemplateByID", id)}
+}
+
+func (_c *Repository_DeleteTemplateByID_Call) Run(run func(id int64)) *Repository_DeleteTemplateByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteTemplateByID_Call) Return(_a0 bool, _a1 error) *Repository_DeleteTemplateByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_DeleteTemplateByID_Call) RunAndReturn(run func(int64) (bool, error)) *Repository_DeleteTemplateByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTemplateByID provides a mock function with given fields: id
+func (_m *Repository) FindTemplateByID(id int64) (*templates.Template, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTemplateByID")
+	}
+
+	var r0 *templates.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64) (*templates.Template, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(int64) *templates.Template); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*templates.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindTemplateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTemplateByID'
+type Repository_FindTemplateByID_Call struct {
+	*mock.Call
+}
+
+// FindTemplateByID is a helper method to define mock.On call
+//   - id int64
+func (_e *Repository_Expecter) FindTemplateByID(id interface{}) *Repository_FindTemplateByID_Call {
+	return &Repository_FindTemplateByID_Call{Call: _e.mock.On("FindTemplateByID", id)}
+}
+
+func (_c *Repository_FindTemplateByID_Call) Run(run func(id int64)) *Repository_FindTemplateByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64))
+	})
+	return _c
+}
+
+func (_c *Repository_FindTemplateByID_Call) Return(_a0 *templates.Template, _a1 error) *Repository_FindTemplateByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindTemplateByID_Call) RunAndReturn(run func(int64) (*templates.Template, error)) *Repository_FindTemplateByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindTemplateVersion provides a mock function with given fields: id, version
+func (_m *Repository) FindTemplateVersion(id int64, version int) (*templates.Template, error) {
+	ret := _m.Called(id, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindTemplateVersion")
+	}
+
+	var r0 *templates.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int) (*templates.Template, error)); ok {
+		return rf(id, version)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int) *templates.Template); ok {
+		r0 = rf(id, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*templates.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int) error); ok {
+		r1 = rf(id, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_FindTemplateVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindTemplateVersion'
+type Repository_FindTemplateVersion_Call struct {
+	*mock.Call
+}
+
+// FindTemplateVersion is a helper method to define mock.On call
+//   - id int64
+//   - version int
+func (_e *Repository_Expecter) FindTemplateVersion(id interface{}, version interface{}) *Repository_FindTemplateVersion_Call {
+	return &Repository_FindTemplateVersion_Call{Call: _e.mock.On("FindTemplateVersion", id, version)}
+}
+
+func (_c *Repository_FindTemplateVersion_Call) Run(run func(id int64, version int)) *Repository_FindTemplateVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *Repository_FindTemplateVersion_Call) Return(_a0 *templates.Template, _a1 error) *Repository_FindTemplateVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_FindTemplateVersion_Call) RunAndReturn(run func(int64, int) (*templates.Template, error)) *Repository_FindTemplateVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTemplates provides a mock function with no fields
+func (_m *Repository) ListTemplates() ([]*templates.Template, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTemplates")
+	}
+
+	var r0 []*templates.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*templates.Template, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*templates.Template); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*templates.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListTemplates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTemplates'
+type Repository_ListTemplates_Call struct {
+	*mock.Call
+}
+
+// ListTemplates is a helper method to define mock.On call
+func (_e *Repository_Expecter) ListTemplates() *Repository_ListTemplates_Call {
+	return &Repository_ListTemplates_Call{Call: _e.mock.On("ListTemplates")}
+}
+
+func (_c *Repository_ListTemplates_Call) Run(run func()) *Repository_ListTemplates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Repository_ListTemplates_Call) Return(_a0 []*templates.Template, _a1 error) *Repository_ListTemplates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListTemplates_Call) RunAndReturn(run func() ([]*templates.Template, error)) *Repository_ListTemplates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTemplateByID provides a mock function with given fields: id, updateTemplate
+func (_m *Repository) UpdateTemplateByID(id int64, updateTemplate *templates.UpdateTemplate) (bool, error) {
+	ret := _m.Called(id, updateTemplate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTemplateByID")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, *templates.UpdateTemplate) (bool, error)); ok {
+		return rf(id, updateTemplate)
+	}
+	if rf, ok := ret.Get(0).(func(int64, *templates.UpdateTemplate) bool); ok {
+		r0 = rf(id, updateTemplate)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, *templates.UpdateTemplate) error); ok {
+		r1 = rf(id, updateTemplate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_UpdateTemplateByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTemplateByID'
+type Repository_UpdateTemplateByID_Call struct {
+	*mock.Call
+}
+
+// UpdateTemplateByID is a helper method to define mock.On call
+//   - id int64
+//   - updateTemplate *templates.UpdateTemplate
+func (_e *Repository_Expecter) UpdateTemplateByID(id interface{}, updateTemplate interface{}) *Repository_UpdateTemplateByID_Call {
+	return &Repository_UpdateTemplateByID_Call{Call: _e.mock.On("UpdateTemplateByID", id, updateTemplate)}
+}
+
+func (_c *Repository_UpdateTemplateByID_Call) Run(run func(id int64, updateTemplate *templates.UpdateTemplate)) *Repository_UpdateTemplateByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(int64), args[1].(*templates.UpdateTemplate))
+	})
+	return _c
+}
+
+func (_c *Repository_UpdateTemplateByID_Call) Return(_a0 bool, _a1 error) *Repository_UpdateTemplateByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_UpdateTemplateByID_Call) RunAndReturn(run func(int64, *templates.UpdateTemplate) (bool, error)) *Repository_UpdateTemplateByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
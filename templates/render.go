@@ -0,0 +1,151 @@
+package templates
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+type Rendered struct {
+	Subject  string
+	Body     string
+	HtmlBody string
+}
+
+type SyntaxError struct {
+	Field string
+	Err   error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// Check parses every part of a template, returning a *SyntaxError for the first
+// part that is not a valid Go template.
+func Check(subject *string, body string, htmlBody *string) error {
+	if subject != nil {
+		if _, err := parseText("subject", *subject); err != nil {
+			return err
+		}
+	}
+
+	if _, err := parseText("body", body); err != nil {
+		return err
+	}
+
+	if htmlBody != nil {
+		if _, err := parseHTML("html_body", *htmlBody); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MissingVariables returns, sorted, the declared variables absent from variables.
+func (t *Template) MissingVariables(variables map[string]any) []string {
+	missing := make([]string, 0)
+
+	for _, name := range t.Variables {
+		if _, ok := variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	sort.Strings(missing)
+
+	return missing
+}
+
+// Render executes the template with variables. The HTML body is escaped with
+// html/template; referencing a variable that was not supplied is an error.
+func (t *Template) Render(variables map[string]any) (*Rendered, error) {
+	if missing := t.MissingVariables(variables); len(missing) > 0 {
+		return nil, fmt.Errorf("missing template variables: %s", strings.Join(missing, ", "))
+	}
+
+	if variables == nil {
+		variables = map[string]any{}
+	}
+
+	var rendered Rendered
+
+	if t.Subject != nil {
+		subject, err := executeText("subject", *t.Subject, variables)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rendered.Subject = subject
+	}
+
+	body, err := executeText("body", t.Body, variables)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rendered.Body = body
+
+	if t.HtmlBody != nil {
+		tmpl, err := parseHTML("html_body", *t.HtmlBody)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var builder strings.Builder
+
+		if err := tmpl.Execute(&builder, variables); err != nil {
+			return nil, err
+		}
+
+		rendered.HtmlBody = builder.String()
+	}
+
+	return &rendered, nil
+}
+
+func executeText(name string, text string, variables map[string]any) (string, error) {
+	tmpl, err := parseText(name, text)
+
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+
+	if err := tmpl.Execute(&builder, variables); err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+func parseText(name string, text string) (*texttemplate.Template, error) {
+	tmpl, err := texttemplate.New(name).Option("missingkey=error").Parse(text)
+
+	if err != nil {
+		return nil, &SyntaxError{Field: name, Err: err}
+	}
+
+	return tmpl, nil
+}
+
+func parseHTML(name string, text string) (*htmltemplate.Template, error) {
+	tmpl, err := htmltemplate.New(name).Option("missingkey=error").Parse(text)
+
+	if err != nil {
+		return nil, &SyntaxError{Field: name, Err: err}
+	}
+
+	return tmpl, nil
+}
@@ -0,0 +1,75 @@
+package templates
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	subject := "Pedido {{.order}}"
+	htmlBody := `<p>Olá, {{.name}}</p>`
+
+	template := &Template{
+		Subject:   &subject,
+		Body:      "Olá, {{.name}}! Seu pedido {{.order}} saiu para entrega.",
+		HtmlBody:  &htmlBody,
+		Variables: []string{"order", "name"},
+	}
+
+	t.Run("Should render every part of the template", func(t *testing.T) {
+		rendered, err := template.Render(map[string]any{"name": "<Maria>", "order": 42})
+		require.Nilf(t, err, "failed to render template: %v", err)
+
+		assert.Equal(t, "Pedido 42", rendered.Subject)
+		assert.Equal(t, "Olá, <Maria>! Seu pedido 42 saiu para entrega.", rendered.Body)
+		assert.Equal(t, "<p>Olá, &lt;Maria&gt;</p>", rendered.HtmlBody)
+	})
+
+	t.Run("Should report missing variables", func(t *testing.T) {
+		_, err := template.Render(map[string]any{})
+
+		assert.Equal(t, []string{"name", "order"}, template.MissingVariables(nil))
+		assert.EqualError(t, err, "missing template variables: name, order")
+	})
+
+	t.Run("Should fail when the template uses an undeclared variable", func(t *testing.T) {
+		undeclared := &Template{Body: "Olá, {{.name}}"}
+
+		_, err := undeclared.Render(nil)
+
+		assert.NotNil(t, err)
+	})
+}
+
+func TestCheck(t *testing.T) {
+	invalid := "{{.name"
+
+	testCases := []struct {
+		name          string
+		subject       *string
+		body          string
+		htmlBody      *string
+		expectedField string
+	}{
+		{"Should accept valid templates", nil, "Olá, {{.name}}", nil, ""},
+		{"Should reject invalid subject", &invalid, "Olá", nil, "subject"},
+		{"Should reject invalid body", nil, invalid, nil, "body"},
+		{"Should reject invalid html body", nil, "Olá", &invalid, "html_body"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Check(tc.subject, tc.body, tc.htmlBody)
+
+			if tc.expectedField == "" {
+				assert.Nil(t, err)
+				return
+			}
+
+			var syntaxError *SyntaxError
+			require.ErrorAs(t, err, &syntaxError)
+			assert.Equal(t, tc.expectedField, syntaxError.Field)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package templates
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+)
+
+type Repository interface {
+	CreateTemplate(createTemplate *CreateTemplate) (int64, error)
+	UpdateTemplateByID(id int64, updateTemplate *UpdateTemplate) (bool, error)
+	FindTemplateByID(id int64) (*Template, error)
+	FindTemplateVersion(id int64, version int) (*Template, error)
+	ListTemplates() ([]*Template, error)
+	DeleteTemplateByID(id int64) (bool, error)
+}
+
+const templateColumns = `t.id, t.name, v.version, v.subject, v.body, v.html_body, v.variables, (
+	SELECT COALESCE(jsonb_object_agg(l.locale, jsonb_build_object('subject', l.subject, 'body', l.body, 'html_body', l.html_body)), '{}')
+	FROM template_localizations l
+	WHERE l.template_id = t.id AND l.version = v.version
+), t.created_at, t.updated_at`
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+type PostgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(db *sql.DB) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) CreateTemplate(createTemplate *CreateTemplate) (int64, error) {
+	var id int64
+
+	err := r.db.QueryRow(`
+		WITH created AS (
+			INSERT INTO templates (name) VALUES ($1) RETURNING id, version
+		), version AS (
+			INSERT INTO template_versions (template_id, version, subject, body, html_body, variables)
+			SELECT id, version, $2, $3, $4, $5 FROM created
+		), localizations AS (
+			INSERT INTO template_localizations (template_id, version, locale, subject, body, html_body)
+			SELECT c.id, c.version, l.key, l.value->>'subject', l.value->>'body', l.value->>'html_body'
+			FROM created c, jsonb_each($6::jsonb) l
+		)
+		SELECT id FROM created`,
+		createTemplate.Name,
+		createTemplate.Subject,
+		createTemplate.Body,
+		createTemplate.HtmlBody,
+		pq.Array(variablesOrEmpty(createTemplate.Variables)),
+		createTemplate.Locales,
+	).Scan(&id)
+
+	if err != nil {
+		var pqErr *pq.Error
+
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return 0, ErrTemplateNameTaken
+		}
+
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// UpdateTemplateByID stores the content as a new version, keeping the previous ones
+// so notifications created with them are still rendered the same way.
+func (r *PostgresRepository) UpdateTemplateByID(id int64, updateTemplate *UpdateTemplate) (bool, error) {
+	err := r.db.QueryRow(`
+		WITH updated AS (
+			UPDATE templates SET version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING id, version
+		), version AS (
+			INSERT INTO template_versions (template_id, version, subject, body, html_body, variables)
+			SELECT id, version, $2, $3, $4, $5 FROM updated
+		), localizations AS (
+			INSERT INTO template_localizations (template_id, version, locale, subject, body, html_body)
+			SELECT u.id, u.version, l.key, l.value->>'subject', l.value->>'body', l.value->>'html_body'
+			FROM updated u, jsonb_each($6::jsonb) l
+		)
+		SELECT id FROM updated`,
+		id,
+		updateTemplate.Subject,
+		updateTemplate.Body,
+		updateTemplate.HtmlBody,
+		pq.Array(variablesOrEmpty(updateTemplate.Variables)),
+		updateTemplate.Locales,
+	).Scan(&id)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *PostgresRepository) FindTemplateByID(id int64) (*Template, error) {
+	row := r.db.QueryRow(`
+		SELECT `+templateColumns+`
+		FROM templates t
+		JOIN template_versions v ON v.template_id = t.id AND v.version = t.version
+		WHERE t.id = $1`, id)
+
+	return findTemplate(row)
+}
+
+func (r *PostgresRepository) FindTemplateVersion(id int64, version int) (*Template, error) {
+	row := r.db.QueryRow(`
+		SELECT `+templateColumns+`
+		FROM templates t
+		JOIN template_versions v ON v.template_id = t.id
+		WHERE t.id = $1 AND v.version = $2`, id, version)
+
+	return findTemplate(row)
+}
+
+func (r *PostgresRepository) ListTemplates() ([]*Template, error) {
+	rows, err := r.db.Query(`
+		SELECT ` + templateColumns + `
+		FROM templates t
+		JOIN template_versions v ON v.template_id = t.id AND v.version = t.version
+		ORDER BY t.name`)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := make([]*Template, 0)
+
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// DeleteTemplateByID removes the template with all its versions, failing with
+// ErrTemplateInUse while any notification references one of them.
+func (r *PostgresRepository) DeleteTemplateByID(id int64) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM templates WHERE id = $1`, id)
+
+	if err != nil {
+		var pqErr *pq.Error
+
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return false, ErrTemplateInUse
+		}
+
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func findTemplate(row rowScanner) (*Template, error) {
+	template, err := scanTemplate(row)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func scanTemplate(row rowScanner) (*Template, error) {
+	var template Template
+	err := row.Scan(
+		&template.Id,
+		&template.Name,
+		&template.Version,
+		&template.Subject,
+		&template.Body,
+		&template.HtmlBody,
+		pq.Array(&template.Variables),
+		&template.Locales,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func variablesOrEmpty(variables []string) []string {
+	if variables == nil {
+		return []string{}
+	}
+
+	return variables
+}
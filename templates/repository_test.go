@@ -0,0 +1,225 @@
+package templates
+
+import (
+	"context"
+	"database/sql"
+	"github.com/Tagliatti/magalu-challenge/database"
+	"github.com/Tagliatti/magalu-challenge/testhelpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type PostgresRepositoryTestSuite struct {
+	suite.Suite
+	pgContainer *testhelpers.PostgresContainer
+	repository  *PostgresRepository
+	db          *sql.DB
+	ctx         context.Context
+}
+
+func (suite *PostgresRepositoryTestSuite) SetupSuite() {
+	suite.ctx = context.Background()
+
+	pgContainer, err := testhelpers.NewPostgresContainer(suite.ctx)
+	require.Nil(suite.T(), err, "failed to start postgres container: %v", err)
+
+	suite.pgContainer = pgContainer
+
+	db, err := database.ConnectTest(pgContainer.ConnectionString)
+	require.Nil(suite.T(), err, "failed to connect to database: %v", err)
+
+	suite.db = db
+	suite.repository = NewPostgresRepository(db)
+}
+
+func (suite *PostgresRepositoryTestSuite) TearDownSuite() {
+	if err := suite.pgContainer.Terminate(suite.ctx); err != nil {
+		suite.T().Fatalf("failed to terminate pgContainer: %s", err)
+	}
+}
+
+func TestPostgresRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(PostgresRepositoryTestSuite))
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessCreateTemplate() {
+	t := suite.T()
+
+	t.Run("Should create template successfully", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		subject := "Pedido {{.order}}"
+
+		id, err := suite.repository.CreateTemplate(&CreateTemplate{
+			Name:      "order-shipped",
+			Subject:   &subject,
+			Body:      "Seu pedido {{.order}} saiu para entrega.",
+			Variables: []string{"order"},
+		})
+		require.Nilf(t, err, "failed to create template: %v", err)
+
+		template, err := suite.repository.FindTemplateByID(id)
+		require.Nilf(t, err, "failed to find template by ID: %v", err)
+		require.NotNil(t, template, "template should not be nil")
+
+		assert.Equal(t, "order-shipped", template.Name)
+		assert.Equal(t, 1, template.Version)
+		assert.Equal(t, subject, *template.Subject)
+		assert.Nil(t, template.HtmlBody)
+		assert.Equal(t, []string{"order"}, template.Variables)
+	})
+
+	t.Run("Should create template with localized variants", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		subject := "Pedido enviado"
+
+		id, err := suite.repository.CreateTemplate(&CreateTemplate{
+			Name: "order-shipped",
+			Body: "Your order shipped",
+			Locales: Locales{
+				"pt-BR": {Subject: &subject, Body: "Seu pedido foi enviado"},
+				"es":    {Body: "Su pedido fue enviado"},
+			},
+		})
+		require.Nilf(t, err, "failed to create template: %v", err)
+
+		updated, err := suite.repository.UpdateTemplateByID(id, &UpdateTemplate{
+			Body:    "Your order is on its way",
+			Locales: Locales{"es": {Body: "Su pedido está en camino"}},
+		})
+		require.Nilf(t, err, "failed to update template: %v", err)
+
+		previous, err := suite.repository.FindTemplateVersion(id, 1)
+		require.Nilf(t, err, "failed to find template version: %v", err)
+
+		current, err := suite.repository.FindTemplateByID(id)
+		require.Nilf(t, err, "failed to find template by ID: %v", err)
+
+		assert.True(t, updated)
+		require.Len(t, previous.Locales, 2)
+		assert.Equal(t, "Seu pedido foi enviado", previous.Locales["pt-BR"].Body)
+		assert.Equal(t, subject, *previous.Locales["pt-BR"].Subject)
+		assert.Nil(t, previous.Locales["es"].HtmlBody)
+		require.Len(t, current.Locales, 1)
+		assert.Equal(t, "Su pedido está en camino", current.Locales["es"].Body)
+	})
+
+	t.Run("Should not create two templates with the same name", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		_, err = suite.repository.CreateTemplate(&CreateTemplate{Name: "order-shipped", Body: "Hello"})
+		require.Nilf(t, err, "failed to create template: %v", err)
+
+		_, err = suite.repository.CreateTemplate(&CreateTemplate{Name: "order-shipped", Body: "Hello"})
+
+		assert.ErrorIs(t, err, ErrTemplateNameTaken)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessUpdateTemplate() {
+	t := suite.T()
+
+	t.Run("Should create a new version keeping the previous one", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateTemplate(&CreateTemplate{Name: "order-shipped", Body: "Hello"})
+		require.Nilf(t, err, "failed to create template: %v", err)
+
+		updated, err := suite.repository.UpdateTemplateByID(id, &UpdateTemplate{Body: "Olá, {{.name}}", Variables: []string{"name"}})
+		require.Nilf(t, err, "failed to update template: %v", err)
+
+		current, err := suite.repository.FindTemplateByID(id)
+		require.Nilf(t, err, "failed to find template by ID: %v", err)
+
+		previous, err := suite.repository.FindTemplateVersion(id, 1)
+		require.Nilf(t, err, "failed to find template version: %v", err)
+
+		assert.True(t, updated)
+		assert.Equal(t, 2, current.Version)
+		assert.Equal(t, "Olá, {{.name}}", current.Body)
+		assert.Equal(t, 1, previous.Version)
+		assert.Equal(t, "Hello", previous.Body)
+	})
+
+	t.Run("Should return false when template does not exist", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		updated, err := suite.repository.UpdateTemplateByID(1, &UpdateTemplate{Body: "Hello"})
+		require.Nilf(t, err, "failed to update template: %v", err)
+
+		assert.False(t, updated)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestSuccessListTemplates() {
+	t := suite.T()
+
+	t.Run("Should list the current version of every template", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		first, err := suite.repository.CreateTemplate(&CreateTemplate{Name: "b-template", Body: "Hello"})
+		require.Nilf(t, err, "failed to create template: %v", err)
+
+		_, err = suite.repository.CreateTemplate(&CreateTemplate{Name: "a-template", Body: "Hello"})
+		require.Nilf(t, err, "failed to create template: %v", err)
+
+		_, err = suite.repository.UpdateTemplateByID(first, &UpdateTemplate{Body: "Hello again"})
+		require.Nilf(t, err, "failed to update template: %v", err)
+
+		list, err := suite.repository.ListTemplates()
+		require.Nilf(t, err, "failed to list templates: %v", err)
+		require.Len(t, list, 2)
+
+		assert.Equal(t, "a-template", list[0].Name)
+		assert.Equal(t, "b-template", list[1].Name)
+		assert.Equal(t, 2, list[1].Version)
+	})
+}
+
+func (suite *PostgresRepositoryTestSuite) TestDeleteTemplate() {
+	t := suite.T()
+
+	t.Run("Should delete unused template", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateTemplate(&CreateTemplate{Name: "order-shipped", Body: "Hello"})
+		require.Nilf(t, err, "failed to create template: %v", err)
+
+		deleted, err := suite.repository.DeleteTemplateByID(id)
+		require.Nilf(t, err, "failed to delete template: %v", err)
+
+		template, err := suite.repository.FindTemplateByID(id)
+		require.Nilf(t, err, "failed to find template by ID: %v", err)
+
+		assert.True(t, deleted)
+		assert.Nil(t, template)
+	})
+
+	t.Run("Should not delete template referenced by notifications", func(t *testing.T) {
+		err := testhelpers.TruncateAllTables(suite.ctx, suite.db)
+		require.Nilf(t, err, "failed to truncate tables: %v", err)
+
+		id, err := suite.repository.CreateTemplate(&CreateTemplate{Name: "order-shipped", Body: "Hello"})
+		require.Nilf(t, err, "failed to create template: %v", err)
+
+		_, err = suite.db.Exec(`
+			INSERT INTO notifications (type, recipient, channels, scheduled_at, deliver_not_before, template_id, template_version)
+			VALUES ('sms', '+5511999999999', '[{"type":"sms","recipient":"+5511999999999"}]', NOW(), NOW(), $1, 1)`, id)
+		require.Nilf(t, err, "failed to create notification: %v", err)
+
+		deleted, err := suite.repository.DeleteTemplateByID(id)
+
+		assert.ErrorIs(t, err, ErrTemplateInUse)
+		assert.False(t, deleted)
+	})
+}
@@ -0,0 +1,40 @@
+package templates
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrTemplateInUse = errors.New("template is referenced by notifications")
+var ErrTemplateNameTaken = errors.New("template name is already taken")
+
+type Template struct {
+	Id        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Version   int       `json:"version"`
+	Subject   *string   `json:"subject"`
+	Body      string    `json:"body"`
+	HtmlBody  *string   `json:"html_body"`
+	Variables []string  `json:"variables"`
+	Locales   Locales   `json:"locales"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CreateTemplate struct {
+	Name      string   `json:"name"`
+	Subject   *string  `json:"subject"`
+	Body      string   `json:"body"`
+	HtmlBody  *string  `json:"html_body" zog:"html_body"`
+	Variables []string `json:"variables"`
+	Locales   Locales  `json:"locales"`
+}
+
+// UpdateTemplate replaces the whole content of a template, creating a new version.
+type UpdateTemplate struct {
+	Subject   *string  `json:"subject"`
+	Body      string   `json:"body"`
+	HtmlBody  *string  `json:"html_body" zog:"html_body"`
+	Variables []string `json:"variables"`
+	Locales   Locales  `json:"locales"`
+}
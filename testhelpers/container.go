@@ -50,7 +50,7 @@ func TruncateAllTables(ctx context.Context, db *sql.DB) error {
 			r RECORD;
 		BEGIN
 			FOR r IN (SELECT tablename FROM pg_tables WHERE schemaname = current_schema()) LOOP
-				EXECUTE 'TRUNCATE TABLE ' || quote_ident(r.tablename) || '';
+				EXECUTE 'TRUNCATE TABLE ' || quote_ident(r.tablename) || ' CASCADE';
 			END LOOP;
 		END $$;
 	`)
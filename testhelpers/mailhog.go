@@ -0,0 +1,59 @@
+package testhelpers
+
+import (
+	"context"
+	"fmt"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"time"
+)
+
+type MailHogContainer struct {
+	testcontainers.Container
+	SMTPHost string
+	SMTPPort string
+	APIURL   string
+}
+
+func NewMailHogContainer(ctx context.Context) (*MailHogContainer, error) {
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "mailhog/mailhog:v1.0.1",
+			ExposedPorts: []string{"1025/tcp", "8025/tcp"},
+			WaitingFor: wait.ForAll(
+				wait.ForListeningPort("1025/tcp"),
+				wait.ForListeningPort("8025/tcp"),
+			).WithDeadline(30 * time.Second),
+		},
+		Started: true,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := container.Host(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	smtpPort, err := container.MappedPort(ctx, "1025/tcp")
+
+	if err != nil {
+		return nil, err
+	}
+
+	apiPort, err := container.MappedPort(ctx, "8025/tcp")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &MailHogContainer{
+		Container: container,
+		SMTPHost:  host,
+		SMTPPort:  smtpPort.Port(),
+		APIURL:    fmt.Sprintf("http://%s:%s", host, apiPort.Port()),
+	}, nil
+}